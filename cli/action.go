@@ -9,17 +9,18 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
-	"sync"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
 	"github.com/YangTaeyoung/hugo-ai-translator/environment"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/git"
+	"github.com/YangTaeyoung/hugo-ai-translator/journal"
 	"github.com/k0kubun/go-ansi"
 	"github.com/manifoldco/promptui"
 	"github.com/openai/openai-go"
 	"github.com/pkg/errors"
 	"github.com/schollz/progressbar/v3"
 	"github.com/urfave/cli/v3"
-	"golang.org/x/sync/errgroup"
 	"gopkg.in/yaml.v3"
 )
 
@@ -81,57 +82,312 @@ var progressbarOpts = []progressbar.Option{
 
 func TranslateAction(ctx context.Context, cmd *cli.Command) error {
 	var (
-		mu      sync.Mutex
 		cfgPath = cmd.String("config")
+		dryRun  = cmd.Bool("dry-run")
+		diff    = cmd.Bool("diff")
 	)
 
+	if cmd.Bool("git") && (dryRun || diff) {
+		return ErrGitPreview
+	}
+	if cmd.Bool("git") && cmd.String("output") != "" {
+		return ErrGitOutput
+	}
+	if cmd.Bool("git") && cmd.Bool("resume") {
+		return ErrGitResume
+	}
+	if cmd.Bool("resume") && file.IsArchive(cmd.String("output")) {
+		return ErrArchiveResume
+	}
+
 	cfg, err := config.New(cfgPath)
 	if err != nil {
 		return err
 	}
 	slog.InfoContext(ctx, "config parsed", "path", cfgPath)
 
-	env := environment.New(cfg)
-	slog.InfoContext(ctx, "environment created")
+	if err = scopeSources(ctx, cmd, cfg); err != nil {
+		return err
+	}
+
+	var jnl *journal.Journal
+	if cmd.Bool("resume") {
+		if jnl, err = openJournal(cfg); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "journal opened", "unfinished", len(jnl.Unfinished()))
+	}
+	if cfg.Sources != nil && len(cfg.Sources) == 0 {
+		fmt.Println("No source files to translate.")
+		return nil
+	}
+
+	var run *gitRun
+	if cmd.Bool("git") {
+		run, err = startGitRun(ctx, cmd, cfg)
+		if errors.Is(err, errNoChangedSources) {
+			fmt.Println("No changed source files to translate.")
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	if cmd.Bool("qa") {
+		cfg.Translator.Quality.Enabled = true
+	}
+	if cmd.Bool("slug") {
+		cfg.Translator.Slug.Enabled = true
+	}
+	if cmd.Bool("write-translation-key") {
+		cfg.Translator.Source.WriteTranslationKey = true
+	}
+	// git 모드는 번역 결과물만 커밋하므로, 작성자의 커밋되지 않은 변경사항이 있을 수 있는 원본 파일은 수정하지 않음
+	if run != nil && cfg.Translator.Source.WriteTranslationKey {
+		slog.WarnContext(ctx, "writing translationKey to source files is disabled with --git")
+		cfg.Translator.Source.WriteTranslationKey = false
+	}
+	if cmd.Bool("update-stale") {
+		cfg.UpdateStale = true
+	}
+	cfg.Output = config.OutputConfig{
+		Path: cmd.String("output"),
+		Diff: diff,
+	}
+	// 이어서 하는 경우 중단된 실행과 같은 곳에 저장
+	if jnl != nil && cfg.Output.Path == "" {
+		cfg.Output.Path = jnl.Options().Output
+	}
 
-	markdownFiles, err := env.Parser.Parse(ctx)
+	env, err := environment.New(cfg)
 	if err != nil {
 		return err
 	}
-	slog.InfoContext(ctx, "markdown files parsed", "count", len(markdownFiles))
-
-	bar := progressbar.NewOptions(len(markdownFiles), progressbarOpts...)
+	slog.InfoContext(ctx, "environment created")
 
-	g, gctx := errgroup.WithContext(ctx)
-	g.SetLimit(8)
-	for _, markdownFile := range markdownFiles {
-		markdownFile := markdownFile
-		bar.Describe(fmt.Sprintf("Translating %s ...", path.Join(markdownFile.OriginDir, markdownFile.FileName+".md")))
+	var (
+		targetPathRule = cfg.Translator.Target.TargetPathRule
+		relocations    []file.Relocation
+	)
+	// --output은 content 디렉터리를 바꾸지 않아야 하므로 번역 결과물을 옮기지 않음
+	if cmd.Bool("detect-renames") && cfg.Output.Path != "" {
+		slog.WarnContext(ctx, "--detect-renames is ignored with --output")
+	}
+	if cmd.Bool("detect-renames") && cfg.Output.Path == "" {
+		relocations, err = findRelocations(ctx, env, cfg.Translator.ContentDir)
+		if err != nil {
+			return err
+		}
 
-		g.Go(func() error {
-			err = env.Translator.Translate(gctx, &markdownFile)
-			if err != nil {
+		if !dryRun && !diff {
+			if err = moveTranslations(ctx, env, relocations); err != nil {
 				return err
 			}
+		}
 
-			if err = env.Writer.Write(gctx, markdownFile); err != nil {
-				slog.ErrorContext(gctx, "failed to write markdown file", "error", err)
-				return nil
-			}
+		if run != nil {
+			run.addRelocations(relocations)
+		}
+	}
 
-			mu.Lock()
-			if err = bar.Add(1); err != nil {
-				return errors.Wrap(err, "failed to update progress bar")
-			}
-			mu.Unlock()
+	contentFiles, err := env.Parser.Parse(ctx)
+	if err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "content files parsed", "count", len(contentFiles))
 
-			return nil
+	if jnl != nil {
+		contentFiles = unfinished(contentFiles, jnl)
+	}
+
+	if dryRun || diff {
+		contentFiles, err = excludeRelocated(targetPathRule, contentFiles, relocations)
+		if err != nil {
+			return err
+		}
+	}
+
+	if dryRun {
+		return printPlan(os.Stdout, targetPathRule, relocations, contentFiles)
+	}
+
+	report := &runReport{minScore: cfg.Translator.Quality.MinScore()}
+	t := translateRun{
+		env:            env,
+		targetPathRule: targetPathRule,
+		failFast:       cmd.Bool("fail-fast"),
+	}
+	if run != nil {
+		t.written = run.addTranslation
+	}
+
+	if cmd.Bool("localize-links") {
+		if t.links, err = env.Parser.LinkLocalizer(ctx, contentFiles, env.Slugger != nil); err != nil {
+			return err
+		}
+	}
+	if env.Slugger != nil {
+		if t.slugs, err = env.Parser.SlugRegistry(ctx); err != nil {
+			return err
+		}
+	}
+
+	// 비정상적으로 종료되더라도 --resume으로 이어서 할 수 있도록 번역을 시작하기 전에 이번 실행의 작업과 옵션을 저장하며,
+	// 이어서 하는 경우 끝난 작업은 제외하고 다시 저장
+	// 아카이브는 다시 만들어지므로 이어서 할 수 없음
+	if !diff && !file.IsArchive(cfg.Output.Path) {
+		t.journal = journal.New(cfg.Translator.ContentDir, contentFiles, journal.Options{
+			UpdateStale: cfg.UpdateStale,
+			Output:      cfg.Output.Path,
 		})
+		if err = t.journal.Save(); err != nil {
+			return err
+		}
 	}
-	if err = g.Wait(); err != nil {
+
+	if err = t.translate(ctx, contentFiles, report); err != nil {
+		_ = env.Writer.Close()
+		_ = report.finish(os.Stdout, cmd.String("report"))
+		if ctx.Err() != nil {
+			if t.journal != nil {
+				fmt.Println("Interrupted. Run again with --resume to translate the remaining files.")
+			}
+			return errors.Wrap(err, "translation interrupted")
+		}
+		return err
+	}
+
+	// 번역 결과물 저장을 마무리하며, --diff이면 diff를 출력
+	if diff {
+		fmt.Println()
+	}
+	if err = env.Writer.Close(); err != nil {
+		return err
+	}
+
+	if err = report.finish(os.Stdout, cmd.String("report")); err != nil {
+		return err
+	}
+
+	if run != nil {
+		if err = run.commit(ctx); err != nil {
+			return err
+		}
+	}
+
+	// 실패한 작업은 journal에 남겨 --resume으로 다시 번역할 수 있게 함
+	if err = report.err(); err != nil {
 		return err
 	}
 
+	// 실패한 작업이 없다면 이어서 할 작업도 없으므로, 이어서 한 경우 남아 있던 journal도 지움
+	if t.journal != nil {
+		return t.journal.Remove()
+	}
+
+	return nil
+}
+
+// scopeSources는 --since, --files 옵션으로 번역할 원본 파일을 cfg.Sources로 제한합니다.
+// 여러 옵션이 함께 지정되면 모든 옵션에 포함된 파일만 번역합니다.
+// --since로 찾은 원본 파일은 수정된 파일이므로, 이미 번역된 언어라도 번역 결과물이 이전 원본을 번역한 것이면 다시 번역합니다.
+func scopeSources(ctx context.Context, cmd *cli.Command, cfg *config.Config) error {
+	contentDir := cfg.Translator.ContentDir
+
+	if since := cmd.String("since"); since != "" {
+		changed, err := git.New(contentDir).ChangedFiles(ctx, since)
+		if err != nil {
+			return errors.Wrapf(err, "failed to detect changed files since %s", since)
+		}
+		slog.InfoContext(ctx, "changed files detected", "since", since, "count", len(changed))
+
+		intersectSources(cfg, changed)
+		cfg.UpdateStale = true
+	}
+
+	if files := cmd.StringSlice("files"); len(files) > 0 {
+		paths, err := contentRelativePaths(contentDir, files)
+		if err != nil {
+			return err
+		}
+
+		intersectSources(cfg, paths)
+	}
+
+	return nil
+}
+
+// intersectSources는 cfg.Sources를 paths와의 교집합으로 제한하며, 제한이 없었다면 paths로 설정합니다.
+func intersectSources(cfg *config.Config, paths []string) {
+	if paths == nil {
+		paths = []string{}
+	}
+
+	if cfg.Sources == nil {
+		cfg.Sources = paths
+		return
+	}
+
+	cfg.Sources = slices.DeleteFunc(cfg.Sources, func(source string) bool {
+		return !slices.Contains(paths, source)
+	})
+}
+
+// contentRelativePaths는 현재 디렉터리 또는 contentDir 기준의 경로를 contentDir 기준 경로로 변환합니다.
+// ex) CI에서 저장소 루트 기준으로 전달된 content/post/a.md -> post/a.md
+func contentRelativePaths(contentDir string, paths []string) ([]string, error) {
+	absContentDir, err := filepath.Abs(contentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]string, 0, len(paths))
+	for _, p := range paths {
+		p = strings.TrimSpace(p)
+		if p == "" {
+			continue
+		}
+
+		absPath, err := filepath.Abs(p)
+		if err != nil {
+			return nil, err
+		}
+
+		if rel, err := filepath.Rel(absContentDir, absPath); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			results = append(results, filepath.ToSlash(rel))
+			continue
+		}
+
+		results = append(results, path.Clean(filepath.ToSlash(p)))
+	}
+
+	return results, nil
+}
+
+// findRelocations는 이름이 바뀐 원본 파일을 찾아 기존 번역 결과물을 옮길 경로를 반환합니다.
+// content 디렉터리가 git 저장소가 아니라면 source_hash만으로 이름이 바뀐 원본을 찾습니다.
+func findRelocations(ctx context.Context, env *environment.Environment, contentDir string) ([]file.Relocation, error) {
+	renames, err := git.New(contentDir).Renames(ctx)
+	if err != nil {
+		if !errors.Is(err, git.ErrNotRepository) {
+			return nil, err
+		}
+		slog.DebugContext(ctx, "content directory is not a git repository", "path", contentDir)
+	}
+
+	return env.Parser.Relocations(ctx, renames)
+}
+
+// moveTranslations는 기존 번역 결과물을 이름이 바뀐 원본 파일의 번역 결과물 경로로 옮깁니다.
+func moveTranslations(ctx context.Context, env *environment.Environment, relocations []file.Relocation) error {
+	for _, relocation := range relocations {
+		if err := env.Writer.Move(ctx, relocation); err != nil {
+			return err
+		}
+		slog.InfoContext(ctx, "translation moved to renamed source", "from", relocation.From, "to", relocation.To)
+	}
+
 	return nil
 }
 
@@ -214,11 +470,11 @@ func ConfigureAction(_ context.Context, cmd *cli.Command) error {
 		return nil
 	}
 
-	if err = os.MkdirAll(filepath.Dir(cfgPath), os.ModePerm); err != nil {
+	if err = os.MkdirAll(filepath.Dir(cfgPath), config.DirMode); err != nil {
 		return errors.Wrap(err, "failed to create config directory")
 	}
 
-	if err = os.WriteFile(cfgPath, configFile, os.ModePerm); err != nil {
+	if err = file.WriteFileAtomic(cfgPath, configFile, config.FileMode); err != nil {
 		return errors.Wrap(err, "failed to write config file")
 	}
 
@@ -239,59 +495,202 @@ func DebugModeAction(ctx context.Context, _ *cli.Command, debug bool) error {
 
 func SimpleTranslateAction(ctx context.Context, cmd *cli.Command) error {
 	var (
-		mu sync.Mutex
+		dryRun = cmd.Bool("dry-run")
+		diff   = cmd.Bool("diff")
 	)
 	cfg, err := config.Simple(cmd)
 	if err != nil {
 		return err
 	}
 
-	env := environment.New(cfg)
+	if cmd.Bool("qa") {
+		cfg.Translator.Quality.Enabled = true
+	}
+	if cmd.Bool("slug") {
+		cfg.Translator.Slug.Enabled = true
+	}
+	if cmd.Bool("write-translation-key") {
+		cfg.Translator.Source.WriteTranslationKey = true
+	}
+	if cmd.Bool("update-stale") {
+		cfg.UpdateStale = true
+	}
+	cfg.Output = config.OutputConfig{
+		Path: cmd.String("output"),
+		Diff: diff,
+	}
+
+	env, err := environment.New(cfg)
+	if err != nil {
+		return err
+	}
 
-	markdownFiles, err := env.Parser.Simple(ctx)
+	contentFiles, err := env.Parser.Simple(ctx)
 	if err != nil {
 		return err
 	}
 
-	slog.InfoContext(ctx, "markdown files parsed",
-		"count", len(markdownFiles),
+	slog.InfoContext(ctx, "content files parsed",
+		"count", len(contentFiles),
 	)
 
-	bar := progressbar.NewOptions(len(markdownFiles), progressbarOpts...)
+	targetPathRule := cfg.Translator.Target.TargetPathRule
 
-	g, gctx := errgroup.WithContext(ctx)
-	g.SetLimit(8)
+	if dryRun {
+		return printPlan(os.Stdout, targetPathRule, nil, contentFiles)
+	}
 
-	for _, markdownFile := range markdownFiles {
-		markdownFile := markdownFile
+	report := &runReport{minScore: cfg.Translator.Quality.MinScore()}
+	t := translateRun{
+		env:            env,
+		targetPathRule: targetPathRule,
+		failFast:       cmd.Bool("fail-fast"),
+	}
+	if cmd.Bool("localize-links") {
+		if t.links, err = env.Parser.LinkLocalizer(ctx, contentFiles, env.Slugger != nil); err != nil {
+			return err
+		}
+	}
+	if env.Slugger != nil {
+		if t.slugs, err = env.Parser.SlugRegistry(ctx); err != nil {
+			return err
+		}
+	}
 
-		g.Go(func() error {
-			bar.Describe(fmt.Sprintf("Translating %s ...", path.Join(markdownFile.OriginDir, markdownFile.FileName+".md")))
+	if err = t.translate(ctx, contentFiles, report); err != nil {
+		_ = env.Writer.Close()
+		_ = report.finish(os.Stdout, cmd.String("report"))
+		if ctx.Err() != nil {
+			return errors.Wrap(err, "translation interrupted")
+		}
+		return err
+	}
 
-			err = env.Translator.Translate(gctx, &markdownFile)
-			if err != nil {
-				return err
-			}
+	// 번역 결과물 저장을 마무리하며, --diff이면 diff를 출력
+	if diff {
+		fmt.Println()
+	}
+	if err = env.Writer.Close(); err != nil {
+		return err
+	}
 
-			if err = env.Writer.Write(gctx, markdownFile); err != nil {
-				return err
-			}
+	if err = report.finish(os.Stdout, cmd.String("report")); err != nil {
+		return err
+	}
 
-			mu.Lock()
-			if err = bar.Add(1); err != nil {
-				return errors.Wrap(err, "failed to update progress bar")
-			}
-			mu.Unlock()
+	return report.err()
+}
 
-			return nil
-		})
+func PruneAction(ctx context.Context, cmd *cli.Command) error {
+	var (
+		cfgPath  = cmd.String("config")
+		dryRun   = cmd.Bool("dry-run")
+		trashDir = cmd.String("trash")
+		yes      = cmd.Bool("yes")
+	)
+
+	cfg, err := config.New(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	env, err := environment.New(cfg)
+	if err != nil {
+		return err
 	}
 
-	if err = g.Wait(); err != nil {
+	orphans, err := env.Parser.Orphans(ctx)
+	if err != nil {
 		return err
 	}
 
-	slog.InfoContext(ctx, "all markdown files written")
+	snapshots, err := env.Parser.UnusedSnapshots(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(orphans) == 0 && len(snapshots) == 0 {
+		fmt.Println("No orphaned translations found.")
+		return nil
+	}
+
+	if len(orphans) > 0 {
+		fmt.Printf("Found %d orphaned translations:\n", len(orphans))
+		for _, orphan := range orphans {
+			fmt.Println("  " + orphan)
+		}
+	}
+	if len(snapshots) > 0 {
+		fmt.Printf("Found %d unused source snapshots in %s.\n", len(snapshots), file.SnapshotDir)
+	}
+
+	if dryRun {
+		return nil
+	}
+
+	if !yes {
+		action := "delete"
+		if trashDir != "" {
+			action = "move to " + trashDir
+		}
+
+		p := promptui.Prompt{
+			Label: fmt.Sprintf("Do you want to %s them? (y/n)", action),
+			Validate: func(s string) error {
+				s = strings.ToLower(s)
+				if !slices.Contains([]string{"y", "n"}, s) {
+					return ErrInvalidInput
+				}
+
+				return nil
+			},
+		}
+
+		answer, err := p.Run()
+		if err != nil {
+			return errors.Wrap(err, "failed to get answer")
+		}
+
+		if strings.ToLower(answer) == "n" {
+			return nil
+		}
+	}
+
+	for _, orphan := range orphans {
+		source := filepath.Join(cfg.Translator.ContentDir, orphan)
+
+		if trashDir == "" {
+			if err = os.Remove(source); err != nil {
+				return errors.Wrapf(err, "failed to delete %s", orphan)
+			}
+			slog.InfoContext(ctx, "orphaned translation deleted", "path", orphan)
+			continue
+		}
+
+		target := filepath.Join(trashDir, orphan)
+		if err = os.MkdirAll(filepath.Dir(target), file.DefaultDirMode); err != nil {
+			return errors.Wrap(err, "failed to create trash directory")
+		}
+
+		if err = os.Rename(source, target); err != nil {
+			return errors.Wrapf(err, "failed to move %s to trash directory", orphan)
+		}
+		slog.InfoContext(ctx, "orphaned translation moved", "path", orphan, "to", target)
+	}
+
+	// 보관된 원본은 번역 결과물이 아니므로 trash로 옮기지 않고 삭제
+	sourceSnapshots := file.NewSourceSnapshots(cfg.Translator.ContentDir)
+	for _, hash := range snapshots {
+		if err = sourceSnapshots.Remove(hash); err != nil {
+			return err
+		}
+		slog.DebugContext(ctx, "unused source snapshot deleted", "hash", hash)
+	}
+
+	fmt.Printf("%d orphaned translations pruned.\n", len(orphans))
+	if len(snapshots) > 0 {
+		fmt.Printf("%d unused source snapshots deleted.\n", len(snapshots))
+	}
 
 	return nil
 }
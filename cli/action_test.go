@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+)
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %s", args, out)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func Test_scopeSources(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "test")
+
+	contentDir := filepath.Join(dir, "content")
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), "# 가\n")
+	writeFile(t, filepath.Join(contentDir, "post", "b.md"), "# 나\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+
+	// 이미 번역된 원본을 수정
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), "# 가나\n")
+
+	tests := []struct {
+		name            string
+		args            []string
+		wantSources     []string
+		wantUpdateStale bool
+	}{
+		{
+			name:            "--since로 찾은 원본은 이전 원본을 번역한 언어도 다시 번역",
+			args:            []string{"--since", "HEAD"},
+			wantSources:     []string{"post/a.md"},
+			wantUpdateStale: true,
+		},
+		{
+			name:            "--files로 지정한 원본",
+			args:            []string{"--files", "post/b.md"},
+			wantSources:     []string{"post/b.md"},
+			wantUpdateStale: false,
+		},
+		{
+			name:            "--since와 --files에 모두 포함된 원본",
+			args:            []string{"--since", "HEAD", "--files", "post/b.md"},
+			wantSources:     []string{},
+			wantUpdateStale: true,
+		},
+		{
+			name:            "옵션이 없으면 모든 원본",
+			args:            nil,
+			wantSources:     nil,
+			wantUpdateStale: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := &config.Config{
+				Translator: config.TranslatorConfig{ContentDir: contentDir},
+			}
+
+			cmd := &cli.Command{
+				Name: "test",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "since"},
+					&cli.StringSliceFlag{Name: "files"},
+				},
+				Action: func(ctx context.Context, cmd *cli.Command) error {
+					return scopeSources(ctx, cmd, cfg)
+				},
+			}
+
+			err := cmd.Run(t.Context(), append([]string{"test"}, tt.args...))
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSources, cfg.Sources)
+			assert.Equal(t, tt.wantUpdateStale, cfg.UpdateStale)
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package cli
 
 import (
+	"time"
+
 	"github.com/urfave/cli/v3"
 )
 
@@ -18,6 +20,92 @@ func NewCommand() *cli.Command {
 				Usage: "re-translate all files",
 				Value: false,
 			},
+			&cli.BoolFlag{
+				Name:  "update-stale",
+				Usage: "translate again languages whose translation was made from an older version of the source",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "detect-renames",
+				Usage: "move existing translations of renamed source files instead of translating them again (ignored with --output)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "localize-links",
+				Usage: "rewrite internal links and ref/relref shortcodes to pages of the target language when they exist",
+				Value: true,
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "print source and target paths to translate without translating",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "diff",
+				Usage: "translate and print unified diffs against existing translations instead of writing them",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:    "output",
+				Aliases: []string{"o"},
+				Usage:   "directory or archive (.tar, .tar.gz, .tgz, .zip) to write translations to instead of the content directory",
+			},
+			&cli.BoolFlag{
+				Name:  "fail-fast",
+				Usage: "stop at the first failed translation instead of translating the remaining files",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:  "report",
+				Usage: "write a JSON report of succeeded, skipped and failed translations to this path",
+			},
+			&cli.BoolFlag{
+				Name:  "qa",
+				Usage: "back-translate each translation and score how well it preserves the meaning of the source (same as quality.enabled in the config file)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "slug",
+				Usage: "write a URL slug in the target language to the front matter of each translation (same as slug.enabled in the config file)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "write-translation-key",
+				Usage: "also write the translationKey copied to translations back to source files without one (same as source.write_translation_key in the config file)",
+				Value: false,
+			},
+			&cli.BoolFlag{
+				Name:  "resume",
+				Usage: "translate only unfinished and failed translations of the last interrupted run",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:  "since",
+				Usage: "translate only source files changed since the git ref (including uncommitted changes)",
+			},
+			&cli.StringSliceFlag{
+				Name:  "files",
+				Usage: "translate only these source files (relative to the current directory or the content directory)",
+			},
+			&cli.BoolFlag{
+				Name:  "git",
+				Usage: "translate only source files changed since --git-base and commit translations on a new branch",
+				Value: false,
+			},
+			&cli.StringFlag{
+				Name:  "git-base",
+				Usage: "base ref to detect changed source files in git mode",
+				Value: "HEAD",
+			},
+			&cli.StringFlag{
+				Name:  "git-branch",
+				Usage: "branch name to commit translations in git mode (default: hugo-ai-translator/<short hash of base>)",
+			},
+			&cli.StringFlag{
+				Name:  "git-commit",
+				Usage: "commit translations per \"language\" or per source \"file\" in git mode",
+				Value: GitCommitPerLanguage,
+			},
 			&cli.BoolFlag{
 				Name:   "debug",
 				Usage:  "debug mode",
@@ -52,7 +140,7 @@ func NewCommand() *cli.Command {
 			},
 			{
 				Name:        "simple",
-				Description: "translate all markdown files in current directory\n",
+				Description: "translate all content files in current directory\n",
 				Flags: []cli.Flag{
 					&cli.StringFlag{
 						Name:    "config",
@@ -80,9 +168,142 @@ func NewCommand() *cli.Command {
 						Usage:   "OpenAI API Key (if don't set, it follows the config file's api key)",
 						Aliases: []string{"k"},
 					},
+					&cli.StringSliceFlag{
+						Name:    "extensions",
+						Usage:   "content file extensions to translate. ex) md, html, adoc, org, rst (if don't set, it follows the config file's extensions or md)",
+						Aliases: []string{"e"},
+					},
+					&cli.BoolFlag{
+						Name:    "recursive",
+						Usage:   "translate content files in sub directories too",
+						Aliases: []string{"r"},
+						Value:   false,
+					},
+					&cli.StringSliceFlag{
+						Name:    "ignore",
+						Usage:   "glob patterns of files to ignore, relative to current directory. ex) drafts/**, *.ko.md",
+						Aliases: []string{"i"},
+					},
+					&cli.BoolFlag{
+						Name:  "skip-translated",
+						Usage: "skip files marked as translated and languages that are already translated",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "update-stale",
+						Usage: "with --skip-translated, translate again languages whose translation was made from an older version of the source",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "localize-links",
+						Usage: "rewrite internal links and ref/relref shortcodes to pages of the target language when they exist",
+						Value: true,
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "print source and target paths to translate without translating",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "diff",
+						Usage: "translate and print unified diffs against existing translations instead of writing them",
+						Value: false,
+					},
+					&cli.StringFlag{
+						Name:    "output",
+						Aliases: []string{"o"},
+						Usage:   "directory or archive (.tar, .tar.gz, .tgz, .zip) to write translations to instead of the content directory",
+					},
+					&cli.BoolFlag{
+						Name:  "fail-fast",
+						Usage: "stop at the first failed translation instead of translating the remaining files",
+						Value: false,
+					},
+					&cli.StringFlag{
+						Name:  "report",
+						Usage: "write a JSON report of succeeded, skipped and failed translations to this path",
+					},
+					&cli.BoolFlag{
+						Name:  "qa",
+						Usage: "back-translate each translation and score how well it preserves the meaning of the source (same as quality.enabled in the config file)",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "slug",
+						Usage: "write a URL slug in the target language to the front matter of each translation (same as slug.enabled in the config file)",
+						Value: false,
+					},
+					&cli.BoolFlag{
+						Name:  "write-translation-key",
+						Usage: "also write the translationKey copied to translations back to source files without one (same as source.write_translation_key in the config file)",
+						Value: false,
+					},
 				},
 				Action: SimpleTranslateAction,
 			},
+			{
+				Name:        "prune",
+				Description: "delete translated files whose source file no longer exists and unused source snapshots",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Usage:   "config file path",
+						Aliases: []string{"c"},
+						Value:   "~/.hugo_ai_translator/config.yaml",
+					},
+					&cli.BoolFlag{
+						Name:  "dry-run",
+						Usage: "only list orphaned translations without deleting them",
+						Value: false,
+					},
+					&cli.StringFlag{
+						Name:  "trash",
+						Usage: "move orphaned translations to this directory instead of deleting them",
+					},
+					&cli.BoolFlag{
+						Name:    "yes",
+						Usage:   "do not ask for confirmation",
+						Aliases: []string{"y"},
+						Value:   false,
+					},
+					&cli.BoolFlag{
+						Name:   "debug",
+						Usage:  "debug mode",
+						Value:  false,
+						Action: DebugModeAction,
+					},
+				},
+				Action: PruneAction,
+			},
+			{
+				Name:        "watch",
+				Description: "watch the content directory and translate source files into all target languages whenever they are saved",
+				Flags: []cli.Flag{
+					&cli.StringFlag{
+						Name:    "config",
+						Usage:   "config file path",
+						Aliases: []string{"c"},
+						Value:   "~/.hugo_ai_translator/config.yaml",
+					},
+					&cli.DurationFlag{
+						Name:  "debounce",
+						Usage: "wait this long after the last save before translating",
+						Value: 500 * time.Millisecond,
+					},
+					&cli.BoolFlag{
+						Name:  "localize-links",
+						Usage: "rewrite internal links and ref/relref shortcodes to pages of the target language when they exist",
+						Value: true,
+					},
+					&cli.BoolFlag{
+						Name:   "debug",
+						Usage:  "debug mode",
+						Value:  false,
+						Action: DebugModeAction,
+					},
+				},
+				Action: WatchAction,
+			},
 		},
 		Action: TranslateAction,
 	}
@@ -0,0 +1,194 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/git"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+)
+
+const (
+	GitCommitPerLanguage = "language"
+	GitCommitPerFile     = "file"
+)
+
+var (
+	ErrInvalidGitCommit = errors.New("invalid git commit mode")
+	ErrGitOutput        = errors.New("--git cannot be used with --output")
+	errNoChangedSources = errors.New("no changed source files")
+)
+
+// gitEntry는 하나의 커밋에 포함될 번역 결과물입니다.
+type gitEntry struct {
+	source   string
+	language config.LanguageCode
+	// from은 이름이 바뀐 원본을 따라 옮겨진 경우의 이전 경로이며, 새로 번역된 경우 빈 문자열입니다.
+	from   string
+	target string
+	// snapshot은 번역한 원본을 보관한 파일의 경로이며, 보관하지 않은 경우 빈 문자열입니다.
+	snapshot string
+}
+
+func (e gitEntry) paths() []string {
+	paths := []string{e.target}
+	if e.from != "" {
+		paths = []string{e.from, e.target}
+	}
+	if e.snapshot != "" {
+		paths = append(paths, e.snapshot)
+	}
+
+	return paths
+}
+
+func (e gitEntry) String() string {
+	if e.from == "" {
+		return fmt.Sprintf("%s -> %s", e.source, e.target)
+	}
+
+	return fmt.Sprintf("%s -> %s (moved from %s)", e.source, e.target, e.from)
+}
+
+// gitRun은 --git 모드에서 번역 결과물을 모아 브랜치에 커밋합니다.
+type gitRun struct {
+	git  *git.Git
+	mode string
+
+	mu      sync.Mutex
+	entries []gitEntry
+}
+
+// startGitRun은 base 이후 변경된 원본 파일로 번역 대상을 제한하고, 번역 결과물을 커밋할 브랜치를 만듭니다.
+// --since, --files로 이미 번역 대상이 제한되어 있다면 그 중 변경된 원본 파일만 번역하며, --since와 같이 이전 원본을 번역한 언어는 다시 번역합니다.
+// 변경된 원본 파일이 없으면 브랜치를 만들지 않고 errNoChangedSources를 반환합니다.
+func startGitRun(ctx context.Context, cmd *cli.Command, cfg *config.Config) (*gitRun, error) {
+	var (
+		base   = cmd.String("git-base")
+		branch = cmd.String("git-branch")
+		mode   = cmd.String("git-commit")
+	)
+
+	if !slices.Contains([]string{GitCommitPerLanguage, GitCommitPerFile}, mode) {
+		return nil, errors.Wrapf(ErrInvalidGitCommit, "%q (must be %q or %q)", mode, GitCommitPerLanguage, GitCommitPerFile)
+	}
+
+	g := git.New(cfg.Translator.ContentDir)
+
+	changed, err := g.ChangedFiles(ctx, base)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to detect changed files since %s", base)
+	}
+	slog.InfoContext(ctx, "changed files detected", "base", base, "count", len(changed))
+
+	intersectSources(cfg, changed)
+	cfg.UpdateStale = true
+	if len(cfg.Sources) == 0 {
+		return nil, errNoChangedSources
+	}
+
+	if branch == "" {
+		hash, err := g.ShortHash(ctx, base)
+		if err != nil {
+			return nil, err
+		}
+		branch = "hugo-ai-translator/" + hash
+	}
+
+	if err = g.CreateBranch(ctx, branch); err != nil {
+		return nil, errors.Wrapf(err, "failed to create branch %s", branch)
+	}
+	slog.InfoContext(ctx, "branch created", "branch", branch)
+
+	return &gitRun{
+		git:  g,
+		mode: mode,
+	}, nil
+}
+
+func (r *gitRun) addTranslation(contentFile file.ContentFile, target string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.entries = append(r.entries, gitEntry{
+		source:   contentFile.SourcePath,
+		language: contentFile.Language,
+		target:   target,
+		// 원본이 수정되었을 때 이전 원본으로 사용할 수 있도록 보관한 원본도 함께 커밋
+		snapshot: path.Join(file.SnapshotDir, file.SourceHash(contentFile.Content)),
+	})
+}
+
+func (r *gitRun) addRelocations(relocations []file.Relocation) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, relocation := range relocations {
+		r.entries = append(r.entries, gitEntry{
+			source:   relocation.Source,
+			language: relocation.Language,
+			from:     relocation.From,
+			target:   relocation.To,
+		})
+	}
+}
+
+// commit은 모아둔 번역 결과물을 언어별 또는 원본 파일별로 커밋합니다.
+// 커밋 순서와 메시지는 번역 순서와 관계없이 항상 같도록 정렬합니다.
+func (r *gitRun) commit(ctx context.Context) error {
+	groups := make(map[string][]gitEntry)
+	for _, entry := range r.entries {
+		key := entry.source
+		if r.mode == GitCommitPerLanguage {
+			key = entry.language.String()
+		}
+		groups[key] = append(groups[key], entry)
+	}
+
+	keys := make([]string, 0, len(groups))
+	for key := range groups {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		entries := groups[key]
+		slices.SortFunc(entries, func(a, b gitEntry) int {
+			return strings.Compare(a.String(), b.String())
+		})
+
+		var (
+			paths []string
+			body  []string
+		)
+		for _, entry := range entries {
+			paths = append(paths, entry.paths()...)
+			body = append(body, "- "+entry.String())
+		}
+
+		message := fmt.Sprintf("Translate %s\n\n%s\n", key, strings.Join(body, "\n"))
+		if r.mode == GitCommitPerLanguage {
+			message = fmt.Sprintf("Translate content into %s (%s)\n\n%s\n", config.LanguageCode(key).Name(), key, strings.Join(body, "\n"))
+		}
+
+		if err := r.git.Commit(ctx, message, paths...); err != nil {
+			if errors.Is(err, git.ErrNothingToCommit) {
+				slog.InfoContext(ctx, "nothing to commit", "group", key)
+				continue
+			}
+
+			return errors.Wrapf(err, "failed to commit translations of %s", key)
+		}
+		slog.InfoContext(ctx, "translations committed", "group", key, "count", len(entries))
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/git"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_gitRun_commit(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	const source = "# 안녕\n"
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "test")
+
+	writeFile(t, filepath.Join(dir, "post", "a.md"), source)
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+
+	// 번역 결과물과 번역한 원본의 보관본이 저장됨
+	snapshots := file.NewSourceSnapshots(dir)
+	assert.NoError(t, snapshots.Save(source))
+	writeFile(t, filepath.Join(dir, "post", "a.en.md"), "# Hello\n")
+	writeFile(t, filepath.Join(dir, "post", "a.ja.md"), "# こんにちは\n")
+
+	run := &gitRun{git: git.New(dir), mode: GitCommitPerLanguage}
+	for _, lang := range []config.LanguageCode{config.LanguageCodeEnglish, config.LanguageCodeJapanese} {
+		run.addTranslation(file.ContentFile{SourcePath: "post/a.md", Language: lang, Content: source}, "post/a."+lang.String()+".md")
+	}
+	assert.NoError(t, run.commit(t.Context()))
+
+	// 번역 결과물과 보관본이 모두 커밋되어 남은 변경사항이 없음
+	status, err := exec.Command("git", "-C", dir, "status", "--porcelain", "--untracked-files=all").Output()
+	assert.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(string(status)))
+
+	committed, err := exec.Command("git", "-C", dir, "log", "--name-only", "--format=", "-n", "2").Output()
+	assert.NoError(t, err)
+	assert.Contains(t, string(committed), path.Join(file.SnapshotDir, file.SourceHash(source)))
+}
@@ -0,0 +1,58 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/pkg/errors"
+)
+
+var ErrGitPreview = errors.New("--git cannot be used with --dry-run or --diff")
+
+// printPlan은 번역하지 않고 옮겨질 번역 결과물과 번역할 원본 -> 번역 결과물의 경로를 출력합니다.
+func printPlan(out io.Writer, targetPathRule string, relocations []file.Relocation, contentFiles file.ContentFiles) error {
+	for _, relocation := range relocations {
+		fmt.Fprintf(out, "move      %s -> %s\n", relocation.From, relocation.To)
+	}
+
+	for _, contentFile := range contentFiles {
+		target, err := file.TargetFilePath(targetPathRule, contentFile)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "translate %s -> %s (%s)\n", contentFile.SourcePath, target, contentFile.Language)
+	}
+
+	fmt.Fprintf(out, "\n%d translations planned, %d translations to move\n", len(contentFiles), len(relocations))
+
+	return nil
+}
+
+// excludeRelocated는 옮겨질 번역 결과물과 경로가 같은 번역 대상을 제외합니다.
+// --dry-run, --diff에서는 번역 결과물을 실제로 옮기지 않으므로, 옮긴 뒤의 번역 대상과 같아지도록 합니다.
+func excludeRelocated(targetPathRule string, contentFiles file.ContentFiles, relocations []file.Relocation) (file.ContentFiles, error) {
+	moved := make(map[string]bool, len(relocations))
+	for _, relocation := range relocations {
+		moved[relocation.To] = true
+	}
+
+	var (
+		results file.ContentFiles
+		err     error
+	)
+	for _, contentFile := range contentFiles {
+		var target string
+		target, err = file.TargetFilePath(targetPathRule, contentFile)
+		if err != nil {
+			return nil, err
+		}
+
+		if !moved[target] {
+			results = append(results, contentFile)
+		}
+	}
+
+	return results, nil
+}
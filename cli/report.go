@@ -0,0 +1,367 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/environment"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/journal"
+	"github.com/YangTaeyoung/hugo-ai-translator/translator"
+	"github.com/pkg/errors"
+	"github.com/schollz/progressbar/v3"
+	"golang.org/x/sync/errgroup"
+)
+
+const (
+	OutcomeSucceeded = "succeeded"
+	OutcomeSkipped   = "skipped"
+	OutcomeFailed    = "failed"
+)
+
+var ErrTranslationFailed = errors.New("translation failed")
+
+// outcome은 원본 파일 하나를 하나의 언어로 번역한 결과입니다.
+type outcome struct {
+	Source   string              `json:"source"`
+	Language config.LanguageCode `json:"language"`
+	Target   string              `json:"target,omitempty"`
+	Status   string              `json:"status"`
+	Error    string              `json:"error,omitempty"`
+	// Score는 --qa로 평가한 번역 품질이며, LowQuality는 Score가 quality.threshold보다 낮은지 여부입니다.
+	Score      *int `json:"score,omitempty"`
+	LowQuality bool `json:"low_quality,omitempty"`
+}
+
+// runReport는 번역 실행 동안의 결과를 모아 요약 표와 JSON 보고서로 출력합니다.
+type runReport struct {
+	// minScore보다 낮은 점수를 받은 번역 결과물은 낮은 품질로 표시합니다.
+	minScore int
+
+	mu       sync.Mutex
+	outcomes []outcome
+}
+
+func (r *runReport) add(contentFile file.ContentFile, target, status string, err error) {
+	o := outcome{
+		Source:   contentFile.SourcePath,
+		Language: contentFile.Language,
+		Target:   target,
+		Status:   status,
+		Score:    contentFile.QualityScore,
+	}
+	if err != nil {
+		o.Error = err.Error()
+	}
+	if o.Score != nil {
+		o.LowQuality = *o.Score < r.minScore
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.outcomes = append(r.outcomes, o)
+}
+
+// sorted는 번역 순서와 관계없이 항상 같도록 원본 파일, 언어 순으로 정렬된 결과를 반환합니다.
+func (r *runReport) sorted() []outcome {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	outcomes := slices.Clone(r.outcomes)
+	slices.SortFunc(outcomes, func(a, b outcome) int {
+		if c := strings.Compare(a.Source, b.Source); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Language.String(), b.Language.String())
+	})
+
+	return outcomes
+}
+
+func (r *runReport) lowQuality() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for _, o := range r.outcomes {
+		if o.LowQuality {
+			n++
+		}
+	}
+
+	return n
+}
+
+func (r *runReport) count(status string) int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var n int
+	for _, o := range r.outcomes {
+		if o.Status == status {
+			n++
+		}
+	}
+
+	return n
+}
+
+// err는 실패한 번역이 있다면 ErrTranslationFailed를 반환합니다.
+func (r *runReport) err() error {
+	failed := r.count(OutcomeFailed)
+	if failed == 0 {
+		return nil
+	}
+
+	return errors.Wrapf(ErrTranslationFailed, "%d of %d translations failed", failed, len(r.sorted()))
+}
+
+// printSummary는 번역 결과를 표로 출력합니다.
+func (r *runReport) printSummary(out io.Writer) error {
+	outcomes := r.sorted()
+	if len(outcomes) == 0 {
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SOURCE\tLANGUAGE\tSTATUS\tSCORE\tTARGET / ERROR")
+	for _, o := range outcomes {
+		detail := o.Target
+		if o.Error != "" {
+			detail = o.Error
+		}
+
+		score := "-"
+		if o.Score != nil {
+			score = strconv.Itoa(*o.Score)
+			if o.LowQuality {
+				score += " (low)"
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", o.Source, o.Language, o.Status, score, detail)
+	}
+	if err := tw.Flush(); err != nil {
+		return errors.Wrap(err, "failed to print summary")
+	}
+
+	fmt.Fprintf(out, "\n%d succeeded, %d skipped, %d failed",
+		r.count(OutcomeSucceeded), r.count(OutcomeSkipped), r.count(OutcomeFailed))
+	if low := r.lowQuality(); low > 0 {
+		fmt.Fprintf(out, ", %d below quality threshold %d", low, r.minScore)
+	}
+	fmt.Fprintln(out)
+
+	return nil
+}
+
+// writeJSON은 번역 결과를 JSON 보고서로 reportPath에 씁니다.
+func (r *runReport) writeJSON(reportPath string) error {
+	report := struct {
+		Succeeded  int       `json:"succeeded"`
+		Skipped    int       `json:"skipped"`
+		Failed     int       `json:"failed"`
+		LowQuality int       `json:"low_quality"`
+		Outcomes   []outcome `json:"outcomes"`
+	}{
+		Succeeded:  r.count(OutcomeSucceeded),
+		Skipped:    r.count(OutcomeSkipped),
+		Failed:     r.count(OutcomeFailed),
+		LowQuality: r.lowQuality(),
+		Outcomes:   r.sorted(),
+	}
+	if report.Outcomes == nil {
+		report.Outcomes = []outcome{}
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal report")
+	}
+
+	if err = file.WriteFileAtomic(reportPath, append(data, '\n'), file.DefaultFileMode); err != nil {
+		return errors.Wrap(err, "failed to write report")
+	}
+
+	return nil
+}
+
+// finish는 요약 표를 출력하고, reportPath가 지정되었다면 JSON 보고서를 씁니다.
+func (r *runReport) finish(out io.Writer, reportPath string) error {
+	fmt.Fprintln(out)
+	if err := r.printSummary(out); err != nil {
+		return err
+	}
+
+	if reportPath == "" {
+		return nil
+	}
+
+	return r.writeJSON(reportPath)
+}
+
+// translateRun은 번역 대상을 번역하고 저장하는 방법입니다.
+type translateRun struct {
+	env            *environment.Environment
+	targetPathRule string
+	// failFast가 true이면 처음 실패한 번역에서 멈추고, 아직 시작하지 않은 번역은 skipped로 기록합니다.
+	failFast bool
+	// written은 번역 결과물이 저장될 때마다 호출되며, nil일 수 있습니다.
+	written func(contentFile file.ContentFile, target string)
+	// journal이 nil이 아니면 번역이 끝나거나 실패할 때마다 기록하여 journal 파일에 저장합니다.
+	journal *journal.Journal
+	// links가 nil이 아니면 번역 결과물의 내부 링크를 번역 대상 언어의 페이지로 바꿉니다.
+	links *file.LinkLocalizer
+	// slugs가 nil이 아니면 env.Slugger로 만든 slug를 겹치지 않게 등록하여 번역 결과물의 front matter에 기록합니다.
+	slugs *file.SlugRegistry
+}
+
+// translate는 contentFiles를 동시에 번역하고 저장하며 각각의 결과를 report에 기록합니다.
+// failFast가 아니라면 실패한 번역이 있어도 나머지를 계속 번역하며, 오류는 report.err로 확인합니다.
+func (t translateRun) translate(ctx context.Context, contentFiles file.ContentFiles, report *runReport) error {
+	var (
+		mu  sync.Mutex
+		bar = progressbar.NewOptions(len(contentFiles), progressbarOpts...)
+	)
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(8)
+	for _, contentFile := range contentFiles {
+		g.Go(func() (err error) {
+			// 결과와 관계없이 진행률을 올려, 실패하거나 건너뛴 대상이 있어도 모든 대상이 끝나면 진행률 표시줄이 완료되도록 함
+			defer func() {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if barErr := bar.Add(1); barErr != nil && err == nil {
+					err = errors.Wrap(barErr, "failed to update progress bar")
+				}
+			}()
+
+			target, err := file.TargetFilePath(t.targetPathRule, contentFile)
+			if err != nil {
+				report.add(contentFile, "", OutcomeFailed, err)
+				t.record(ctx, contentFile, err)
+				if t.failFast {
+					return err
+				}
+				return nil
+			}
+
+			// 앞선 번역이 실패해 멈췄거나 실행이 취소되었다면 번역하지 않음
+			if err = gctx.Err(); err != nil {
+				report.add(contentFile, target, OutcomeSkipped, nil)
+				return nil
+			}
+
+			mu.Lock()
+			bar.Describe(fmt.Sprintf("Translating %s ...", path.Join(contentFile.OriginDir, contentFile.FileName+contentFile.Ext)))
+			mu.Unlock()
+
+			if contentFile, err = t.translateFile(gctx, contentFile); err != nil {
+				// 다른 번역이 실패해 멈췄거나 실행이 취소되어 중단된 번역
+				if gctx.Err() != nil {
+					report.add(contentFile, target, OutcomeSkipped, nil)
+					return nil
+				}
+
+				report.add(contentFile, target, OutcomeFailed, err)
+				slog.ErrorContext(gctx, "failed to translate content file", "source", contentFile.SourcePath, "language", contentFile.Language, "error", err)
+				t.record(ctx, contentFile, err)
+				if t.failFast {
+					return err
+				}
+				return nil
+			}
+
+			report.add(contentFile, target, OutcomeSucceeded, nil)
+			t.record(ctx, contentFile, nil)
+			if t.written != nil {
+				t.written(contentFile, target)
+			}
+
+			return nil
+		})
+	}
+
+	if err := g.Wait(); err != nil {
+		return err
+	}
+
+	// 실행이 취소되어 번역하지 못한 대상은 skipped로 기록되므로, 취소 여부를 따로 반환
+	return ctx.Err()
+}
+
+// translateFile은 contentFile을 번역하고 저장하며, 품질을 평가했다면 점수가 담긴 contentFile을 반환합니다.
+func (t translateRun) translateFile(ctx context.Context, contentFile file.ContentFile) (file.ContentFile, error) {
+	if err := t.env.Translator.Translate(ctx, &contentFile); err != nil {
+		return contentFile, err
+	}
+
+	if t.links != nil {
+		contentFile.Translated = t.links.Localize(contentFile)
+	}
+
+	if t.slugs != nil {
+		if err := localizeSlug(ctx, t.env.Slugger, t.slugs, &contentFile); err != nil {
+			return contentFile, err
+		}
+	}
+
+	// 품질 평가에 실패하거나 평가 중 실행이 중단되더라도 번역 결과물은 점수 없이 저장
+	if t.env.Estimator != nil {
+		if err := t.env.Estimator.Estimate(ctx, &contentFile); err != nil && ctx.Err() == nil {
+			slog.WarnContext(ctx, "failed to estimate translation quality", "source", contentFile.SourcePath, "language", contentFile.Language, "error", err)
+		}
+	}
+
+	// 번역이 끝난 뒤 실행이 중단되더라도 번역 결과물은 끝까지 저장
+	return contentFile, t.env.Writer.Write(context.WithoutCancel(ctx), contentFile)
+}
+
+// localizeSlug는 contentFile의 번역 결과물에 사용할 slug를 만들어 slugs에 등록하고 contentFile.Slug에 담습니다.
+// 이미 있는 번역 결과물에 slug가 있다면 URL이 바뀌지 않도록 새로 만들지 않고 그대로 사용합니다.
+func localizeSlug(ctx context.Context, slugger translator.Slugger, slugs *file.SlugRegistry, contentFile *file.ContentFile) error {
+	// 이미 있는 번역 결과물의 slug는 SlugRegistry를 만들 때 등록됨
+	if slug := contentFile.PreviousSlug(); slug != "" {
+		contentFile.Slug = slug
+		return nil
+	}
+
+	slug, err := slugger.Slug(ctx, *contentFile)
+	if err != nil {
+		return err
+	}
+
+	contentFile.Slug, err = slugs.Reserve(*contentFile, slug)
+
+	return err
+}
+
+// record는 번역 결과를 journal에 기록하며, 저장하지 못하더라도 번역 결과는 그대로 둡니다.
+func (t translateRun) record(ctx context.Context, contentFile file.ContentFile, cause error) {
+	if t.journal == nil {
+		return
+	}
+
+	var err error
+	if cause != nil {
+		err = t.journal.Fail(contentFile.SourcePath, contentFile.Language, cause)
+	} else {
+		err = t.journal.Done(contentFile.SourcePath, contentFile.Language)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to save journal", "path", contentFile.SourcePath, "language", contentFile.Language, "error", err)
+	}
+}
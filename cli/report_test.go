@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/journal"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestReport() *runReport {
+	score, lowScore := 90, 40
+
+	r := &runReport{minScore: 70}
+	r.add(file.ContentFile{SourcePath: "post/b.md", Language: config.LanguageCodeJapanese}, "", OutcomeFailed, errors.New("boom"))
+	r.add(file.ContentFile{SourcePath: "post/a.md", Language: config.LanguageCodeJapanese, QualityScore: &lowScore}, "post/a.ja.md", OutcomeSucceeded, nil)
+	r.add(file.ContentFile{SourcePath: "post/a.md", Language: config.LanguageCodeEnglish, QualityScore: &score}, "post/a.en.md", OutcomeSucceeded, nil)
+	r.add(file.ContentFile{SourcePath: "post/b.md", Language: config.LanguageCodeEnglish}, "post/b.en.md", OutcomeSkipped, nil)
+
+	return r
+}
+
+func Test_runReport_printSummary(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *runReport
+		want   string
+	}{
+		{
+			name:   "원본 파일, 언어 순으로 결과와 요약을 출력",
+			report: newTestReport(),
+			want: "SOURCE     LANGUAGE  STATUS     SCORE     TARGET / ERROR\n" +
+				"post/a.md  en        succeeded  90        post/a.en.md\n" +
+				"post/a.md  ja        succeeded  40 (low)  post/a.ja.md\n" +
+				"post/b.md  en        skipped    -         post/b.en.md\n" +
+				"post/b.md  ja        failed     -         boom\n" +
+				"\n2 succeeded, 1 skipped, 1 failed, 1 below quality threshold 70\n",
+		},
+		{
+			name:   "결과가 없으면 출력하지 않음",
+			report: &runReport{},
+			want:   "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NoError(t, tt.report.printSummary(&out))
+			assert.Equal(t, tt.want, out.String())
+		})
+	}
+}
+
+func Test_runReport_writeJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		report *runReport
+		want   string
+	}{
+		{
+			name:   "요약과 정렬된 결과",
+			report: newTestReport(),
+			want: `{
+  "succeeded": 2,
+  "skipped": 1,
+  "failed": 1,
+  "low_quality": 1,
+  "outcomes": [
+    {"source": "post/a.md", "language": "en", "target": "post/a.en.md", "status": "succeeded", "score": 90},
+    {"source": "post/a.md", "language": "ja", "target": "post/a.ja.md", "status": "succeeded", "score": 40, "low_quality": true},
+    {"source": "post/b.md", "language": "en", "target": "post/b.en.md", "status": "skipped"},
+    {"source": "post/b.md", "language": "ja", "status": "failed", "error": "boom"}
+  ]
+}`,
+		},
+		{
+			name:   "결과가 없으면 빈 배열",
+			report: &runReport{},
+			want:   `{"succeeded": 0, "skipped": 0, "failed": 0, "low_quality": 0, "outcomes": []}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reportPath := filepath.Join(t.TempDir(), "report.json")
+			assert.NoError(t, tt.report.writeJSON(reportPath))
+
+			got, err := os.ReadFile(reportPath)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(got))
+		})
+	}
+}
+
+func Test_runReport_err(t *testing.T) {
+	assert.ErrorIs(t, newTestReport().err(), ErrTranslationFailed)
+	assert.NoError(t, (&runReport{}).err())
+}
+
+func Test_translateRun_translate_TargetPathError(t *testing.T) {
+	contentDir := t.TempDir()
+	contentFiles := file.ContentFiles{
+		{SourcePath: "post/a.md", OriginDir: "post", FileName: "a", Ext: ".md", Language: config.LanguageCodeEnglish},
+	}
+
+	jnl := journal.New(contentDir, contentFiles, journal.Options{})
+
+	// front matter에 slug가 없으므로 target path를 만들 수 없음
+	run := translateRun{
+		targetPathRule: "{origin}/{slug}.{language}.md",
+		journal:        jnl,
+	}
+	report := &runReport{}
+	assert.NoError(t, run.translate(t.Context(), contentFiles, report))
+
+	assert.Equal(t, 1, report.count(OutcomeFailed))
+	if entries := jnl.Entries(); assert.Len(t, entries, 1) {
+		assert.Equal(t, journal.StatusFailed, entries[0].Status)
+	}
+
+	// 실패한 작업은 실행이 끝나기 전에 이미 journal 파일에 저장되어 있음
+	reopened, err := journal.Open(contentDir)
+	assert.NoError(t, err)
+	assert.Equal(t, jnl.Entries(), reopened.Entries())
+}
+
+type fakeSlugger struct {
+	slug  string
+	calls int
+}
+
+func (s *fakeSlugger) Slug(context.Context, file.ContentFile) (string, error) {
+	s.calls++
+	return s.slug, nil
+}
+
+func Test_localizeSlug(t *testing.T) {
+	tests := []struct {
+		name      string
+		previous  file.Markdown
+		want      string
+		wantCalls int
+	}{
+		{
+			name:      "이전 번역 결과물의 slug는 그대로 사용",
+			previous:  "---\ntitle: こんにちは\nslug: konnichiwa\n---\n\nbody\n",
+			want:      "konnichiwa",
+			wantCalls: 0,
+		},
+		{
+			name:      "새 번역 결과물은 slug를 만듦",
+			previous:  "",
+			want:      "sekai",
+			wantCalls: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slugs, err := file.NewParser(file.ParserConfig{
+				ContentDir:      t.TempDir(),
+				SourceLanguage:  config.LanguageCodeKorean,
+				TargetLanguages: config.LanguageCodes{config.LanguageCodeJapanese},
+				TargetPathRule:  config.SimpleTargetPathRule,
+			}).SlugRegistry(t.Context())
+			assert.NoError(t, err)
+
+			slugger := &fakeSlugger{slug: "sekai"}
+			contentFile := file.ContentFile{OriginDir: "post", FileName: "foo", Ext: ".md", Language: config.LanguageCodeJapanese, Previous: tt.previous}
+
+			assert.NoError(t, localizeSlug(t.Context(), slugger, slugs, &contentFile))
+			assert.Equal(t, tt.want, contentFile.Slug)
+			assert.Equal(t, tt.wantCalls, slugger.calls)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/journal"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrGitResume     = errors.New("--git cannot be used with --resume")
+	ErrArchiveResume = errors.New("--resume cannot be used with an archive --output")
+)
+
+// openJournal은 --resume으로 이어서 할 journal을 읽고, 번역 대상을 끝나지 않은 작업의 원본 파일로 제한합니다.
+// 중단된 실행이 원본이 수정된 언어도 다시 번역했다면, 이번 실행에서 --update-stale을 지정하지 않아도 다시 번역합니다.
+func openJournal(cfg *config.Config) (*journal.Journal, error) {
+	j, err := journal.Open(cfg.Translator.ContentDir)
+	if err != nil {
+		if errors.Is(err, journal.ErrNotFound) {
+			return nil, errors.Wrapf(err, "nothing to resume in %s", cfg.Translator.ContentDir)
+		}
+		return nil, err
+	}
+
+	var sources []string
+	for _, entry := range j.Unfinished() {
+		sources = append(sources, entry.Source)
+	}
+	intersectSources(cfg, sources)
+
+	if j.Options().UpdateStale {
+		cfg.UpdateStale = true
+	}
+
+	return j, nil
+}
+
+// unfinished는 contentFiles 중 journal에서 끝나지 않은 작업만 반환합니다.
+func unfinished(contentFiles file.ContentFiles, j *journal.Journal) file.ContentFiles {
+	pending := make(map[string]bool)
+	for _, entry := range j.Unfinished() {
+		pending[entry.Source+"\x00"+entry.Language.String()] = true
+	}
+
+	var results file.ContentFiles
+	for _, contentFile := range contentFiles {
+		if pending[contentFile.SourcePath+"\x00"+contentFile.Language.String()] {
+			results = append(results, contentFile)
+		}
+	}
+
+	return results
+}
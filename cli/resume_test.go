@@ -0,0 +1,56 @@
+package cli
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/journal"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_openJournal_UpdateStale(t *testing.T) {
+	const (
+		oldSource = "# 안녕\n"
+		newSource = "# 안녕하세요\n"
+	)
+
+	contentDir := t.TempDir()
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), newSource)
+	writeFile(t, filepath.Join(contentDir, "post", "a.en.md"), "---\ntranslated: true\nsource_hash: "+file.SourceHash(oldSource)+"\n---\n# Hello\n")
+	writeFile(t, filepath.Join(contentDir, "post", "b.md"), newSource)
+	writeFile(t, filepath.Join(contentDir, "post", "b.en.md"), "---\ntranslated: true\nsource_hash: "+file.SourceHash(oldSource)+"\n---\n# Hello\n")
+
+	// --update-stale로 실행하여 b.md만 다시 번역한 뒤 중단됨
+	interrupted := journal.New(contentDir, file.ContentFiles{
+		{SourcePath: "post/a.md", Language: config.LanguageCodeEnglish},
+		{SourcePath: "post/b.md", Language: config.LanguageCodeEnglish},
+	}, journal.Options{UpdateStale: true})
+	assert.NoError(t, interrupted.Done("post/b.md", config.LanguageCodeEnglish))
+
+	// --update-stale 없이 --resume으로 이어서 함
+	cfg := &config.Config{}
+	cfg.Translator.ContentDir = contentDir
+
+	j, err := openJournal(cfg)
+	assert.NoError(t, err)
+	assert.True(t, cfg.UpdateStale)
+	assert.Equal(t, []string{"post/a.md"}, cfg.Sources)
+
+	contentFiles, err := file.NewParser(file.ParserConfig{
+		ContentDir:      contentDir,
+		TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish},
+		TargetPathRule:  "{origin}/{fileName}.{language}.md",
+		SourceLanguage:  config.LanguageCodeKorean,
+		Sources:         cfg.Sources,
+		UpdateStale:     cfg.UpdateStale,
+	}).Parse(t.Context())
+	assert.NoError(t, err)
+
+	resumed := unfinished(contentFiles, j)
+	if assert.Len(t, resumed, 1) {
+		assert.Equal(t, "post/a.md", resumed[0].SourcePath)
+		assert.Equal(t, config.LanguageCodeEnglish, resumed[0].Language)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
 	"github.com/manifoldco/promptui"
 	"github.com/pkg/errors"
 )
@@ -175,14 +176,18 @@ const targetPathRuleDescription = `
 !!!VERY IMPORTANT!!!
 The target path rule is a rule that determines the path of the translated file.
 You can use the following variables in the rule:
-- {language}: the language code of the target language
+- {language}: the language code of the target language (required)
 - {origin}: the origin path of the source file directory path (Note: not include filename and extension)
-- {filename}: the name of the source file
+- {fileName}: the name of the source file without extension ({filename} is also allowed)
+- {ext}: the extension of the source file without dot (ex. md, html)
+- {section}: the first directory of {origin}
+- {slug}, {translationKey}, ...: any value of the source file's front matter
+- {if slug}...{else}...{end}: use the first path only when the value is not empty
 
 # Example
 content directory: ~/hugo_root/content
 target languages: [en, fr]
-target path rule: "{origin}/{filename}.{language}.md"
+target path rule: "{origin}/{fileName}.{language}.md"
 current file system:
 	~/hugo_root
 	└── content
@@ -195,8 +200,8 @@ current file system:
 the translated file saved in:
 - ~/hugo_root/content/_index.en.md
 - ~/hugo_root/content/_index.fr.md
-- ~/hugo_root/content/some-posting.md.en.md
-- ~/hugo_root/content/some-posting.md.fr.md
+- ~/hugo_root/content/some-posting.en.md
+- ~/hugo_root/content/some-posting.fr.md
 - ~/hugo_root/content/some-posting2/index.en.md
 - ~/hugo_root/content/some-posting2/index.fr.md`
 
@@ -216,7 +221,9 @@ func targetPathRuleStep(cfg *config.Config) error {
 				return ErrEmptyInput
 			}
 
-			return nil
+			_, err := pathrule.Parse(s)
+
+			return err
 		},
 	}
 
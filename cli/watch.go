@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"context"
+	"io/fs"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/environment"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+	"github.com/urfave/cli/v3"
+	"golang.org/x/sync/errgroup"
+)
+
+// WatchAction은 content 디렉터리를 감시하다가 원본 파일이 저장되면 모든 대상 언어로 다시 번역합니다.
+func WatchAction(ctx context.Context, cmd *cli.Command) error {
+	cfgPath := cmd.String("config")
+
+	cfg, err := config.New(cfgPath)
+	if err != nil {
+		return err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "failed to create file watcher")
+	}
+	defer watcher.Close()
+
+	contentDir := cfg.Translator.ContentDir
+	if err = watchDirs(watcher, contentDir); err != nil {
+		return err
+	}
+
+	// 원본 파일에 translationKey를 기록하면 다시 번역하게 되므로, watch에서는 번역 결과물에만 기록
+	cfg.Translator.Source.WriteTranslationKey = false
+
+	env, err := environment.New(cfg)
+	if err != nil {
+		return err
+	}
+	defer env.Writer.Close()
+
+	w := newTranslationWatcher(cmd.Duration("debounce"), nil)
+	w.translateFile = func(ctx context.Context, filePath string) {
+		translateWatchedFile(ctx, env, cmd.Bool("localize-links"), filePath)
+	}
+	defer w.stop()
+
+	slog.InfoContext(ctx, "watching content directory", "path", contentDir)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			slog.ErrorContext(ctx, "file watcher error", "error", err)
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			relPath, err := filepath.Rel(contentDir, event.Name)
+			// journal, 보관한 원본처럼 숨김 디렉터리 안의 파일은 번역 대상이 아니므로 감시하지 않음
+			if err != nil || isHiddenPath(relPath) {
+				continue
+			}
+
+			// 새로 만들어진 디렉터리도 감시 대상에 추가
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					if err = watchDirs(watcher, event.Name); err != nil {
+						slog.ErrorContext(ctx, "failed to watch directory", "path", event.Name, "error", err)
+					}
+					continue
+				}
+			}
+
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			w.schedule(ctx, relPath)
+		}
+	}
+}
+
+// isHiddenPath는 contentDir 기준 경로 relPath가 숨김 파일이거나 숨김 디렉터리 안에 있는지 여부입니다.
+func isHiddenPath(relPath string) bool {
+	for _, name := range strings.Split(filepath.ToSlash(relPath), "/") {
+		if name != "." && name != ".." && strings.HasPrefix(name, ".") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// watchDirs는 root와 숨김 디렉터리를 제외한 모든 하위 디렉터리를 감시 대상에 추가합니다.
+// fsnotify는 하위 디렉터리를 재귀적으로 감시하지 않으므로 디렉터리마다 추가해야 합니다.
+func watchDirs(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			return nil
+		}
+		if filePath != root && strings.HasPrefix(d.Name(), ".") {
+			return filepath.SkipDir
+		}
+
+		return errors.Wrapf(watcher.Add(filePath), "failed to watch %s", filePath)
+	})
+}
+
+type runningTranslation struct {
+	cancel context.CancelFunc
+}
+
+// pendingTranslation은 debounce 중인 번역으로, 타이머가 만료되었을 때 자신이 가장 최근에 예약된 번역인지 확인하는 데 사용합니다.
+type pendingTranslation struct {
+	timer *time.Timer
+}
+
+// translationWatcher는 파일별로 저장 이벤트를 debounce하고, 같은 파일이 다시 저장되면 진행 중인 번역을 취소합니다.
+type translationWatcher struct {
+	debounce time.Duration
+	// translateFile은 ctx가 취소될 때까지 filePath를 번역합니다.
+	translateFile func(ctx context.Context, filePath string)
+
+	mu      sync.Mutex
+	stopped bool
+	timers  map[string]*pendingTranslation
+	running map[string]*runningTranslation
+	wg      sync.WaitGroup
+}
+
+func newTranslationWatcher(debounce time.Duration, translateFile func(ctx context.Context, filePath string)) *translationWatcher {
+	return &translationWatcher{
+		debounce:      debounce,
+		translateFile: translateFile,
+		timers:        make(map[string]*pendingTranslation),
+		running:       make(map[string]*runningTranslation),
+	}
+}
+
+// schedule은 debounce 이후 filePath의 번역을 시작하며, 같은 파일의 번역이 진행 중이면 취소합니다.
+func (w *translationWatcher) schedule(ctx context.Context, filePath string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.stopped {
+		return
+	}
+
+	if running, ok := w.running[filePath]; ok {
+		running.cancel()
+		delete(w.running, filePath)
+		slog.DebugContext(ctx, "in-flight translation cancelled", "path", filePath)
+	}
+
+	if pending, ok := w.timers[filePath]; ok {
+		pending.timer.Stop()
+	}
+
+	pending := &pendingTranslation{}
+	pending.timer = time.AfterFunc(w.debounce, func() {
+		w.translate(ctx, filePath, pending)
+	})
+	w.timers[filePath] = pending
+}
+
+// translate는 pending이 filePath에 가장 최근에 예약된 번역일 때만 번역을 시작합니다.
+// 타이머가 만료된 직후 다시 예약되었다면 Stop으로 멈출 수 없으므로, 이전 타이머는 아무것도 하지 않고 새 타이머에 맡깁니다.
+func (w *translationWatcher) translate(ctx context.Context, filePath string, pending *pendingTranslation) {
+	w.mu.Lock()
+	if w.stopped || w.timers[filePath] != pending {
+		w.mu.Unlock()
+		return
+	}
+
+	// 같은 파일의 번역이 동시에 결과물을 쓰지 않도록 진행 중인 번역은 취소
+	if running, ok := w.running[filePath]; ok {
+		running.cancel()
+	}
+
+	tctx, cancel := context.WithCancel(ctx)
+	running := &runningTranslation{cancel: cancel}
+	delete(w.timers, filePath)
+	w.running[filePath] = running
+	w.wg.Add(1)
+	w.mu.Unlock()
+
+	defer func() {
+		w.mu.Lock()
+		if w.running[filePath] == running {
+			delete(w.running, filePath)
+		}
+		w.mu.Unlock()
+
+		cancel()
+		w.wg.Done()
+	}()
+
+	w.translateFile(tctx, filePath)
+}
+
+// translateWatchedFile은 저장된 원본 파일 filePath를 모든 대상 언어로 번역하여 저장합니다.
+func translateWatchedFile(ctx context.Context, env *environment.Environment, localizeLinks bool, filePath string) {
+	contentFiles, err := env.Parser.ParseFile(ctx, filePath)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to parse content file", "path", filePath, "error", err)
+		return
+	}
+	if len(contentFiles) == 0 {
+		return
+	}
+
+	slog.InfoContext(ctx, "translating content file", "path", filePath, "languages", len(contentFiles))
+
+	var links *file.LinkLocalizer
+	if localizeLinks {
+		if links, err = env.Parser.LinkLocalizer(ctx, contentFiles, env.Slugger != nil); err != nil {
+			slog.ErrorContext(ctx, "failed to find translated pages", "path", filePath, "error", err)
+			return
+		}
+	}
+
+	var slugs *file.SlugRegistry
+	if env.Slugger != nil {
+		if slugs, err = env.Parser.SlugRegistry(ctx); err != nil {
+			slog.ErrorContext(ctx, "failed to find slugs of translated pages", "path", filePath, "error", err)
+			return
+		}
+	}
+
+	g, gctx := errgroup.WithContext(ctx)
+	g.SetLimit(8)
+	for _, contentFile := range contentFiles {
+		g.Go(func() error {
+			if err := env.Translator.Translate(gctx, &contentFile); err != nil {
+				return err
+			}
+			if links != nil {
+				contentFile.Translated = links.Localize(contentFile)
+			}
+			if slugs != nil {
+				if err := localizeSlug(gctx, env.Slugger, slugs, &contentFile); err != nil {
+					return err
+				}
+			}
+
+			// 번역하는 동안 원본이 다시 저장되었다면 이전 내용의 번역은 쓰지 않음
+			if err := gctx.Err(); err != nil {
+				return err
+			}
+
+			return env.Writer.Write(gctx, contentFile)
+		})
+	}
+
+	if err = g.Wait(); err != nil {
+		if errors.Is(err, context.Canceled) {
+			slog.DebugContext(ctx, "translation cancelled", "path", filePath)
+			return
+		}
+
+		slog.ErrorContext(ctx, "failed to translate content file", "path", filePath, "error", err)
+		return
+	}
+
+	slog.InfoContext(ctx, "content file translated", "path", filePath)
+}
+
+// stop은 예약된 번역을 취소하고 진행 중인 번역이 끝날 때까지 기다립니다.
+func (w *translationWatcher) stop() {
+	w.mu.Lock()
+	w.stopped = true
+	for _, pending := range w.timers {
+		pending.timer.Stop()
+	}
+	for _, running := range w.running {
+		running.cancel()
+	}
+	w.mu.Unlock()
+
+	w.wg.Wait()
+}
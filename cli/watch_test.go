@@ -0,0 +1,178 @@
+package cli
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeTranslations는 번역 요청을 기록하며, block이 true이면 ctx가 취소될 때까지 번역을 끝내지 않습니다.
+type fakeTranslations struct {
+	block bool
+
+	mu        sync.Mutex
+	started   []string
+	cancelled []string
+}
+
+func (f *fakeTranslations) translate(ctx context.Context, filePath string) {
+	f.mu.Lock()
+	f.started = append(f.started, filePath)
+	f.mu.Unlock()
+
+	if !f.block {
+		return
+	}
+
+	<-ctx.Done()
+
+	f.mu.Lock()
+	f.cancelled = append(f.cancelled, filePath)
+	f.mu.Unlock()
+}
+
+func (f *fakeTranslations) counts() (started, cancelled int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.started), len(f.cancelled)
+}
+
+func Test_translationWatcher_schedule(t *testing.T) {
+	t.Run("연속된 저장은 한 번만 번역", func(t *testing.T) {
+		fake := &fakeTranslations{}
+		w := newTranslationWatcher(20*time.Millisecond, fake.translate)
+
+		for range 5 {
+			w.schedule(t.Context(), "post/foo.md")
+		}
+		w.schedule(t.Context(), "post/bar.md")
+
+		assert.Eventually(t, func() bool {
+			started, _ := fake.counts()
+			return started == 2
+		}, time.Second, 5*time.Millisecond)
+		w.stop()
+
+		assert.ElementsMatch(t, []string{"post/foo.md", "post/bar.md"}, fake.started)
+	})
+
+	t.Run("번역 중 다시 저장하면 진행 중인 번역을 취소", func(t *testing.T) {
+		fake := &fakeTranslations{block: true}
+		w := newTranslationWatcher(time.Millisecond, fake.translate)
+
+		w.schedule(t.Context(), "post/foo.md")
+		assert.Eventually(t, func() bool {
+			started, _ := fake.counts()
+			return started == 1
+		}, time.Second, time.Millisecond)
+
+		w.schedule(t.Context(), "post/foo.md")
+		assert.Eventually(t, func() bool {
+			started, cancelled := fake.counts()
+			return started == 2 && cancelled == 1
+		}, time.Second, time.Millisecond)
+
+		w.stop()
+		_, cancelled := fake.counts()
+		assert.Equal(t, 2, cancelled)
+	})
+
+	t.Run("stop 이후에는 번역하지 않음", func(t *testing.T) {
+		fake := &fakeTranslations{}
+		w := newTranslationWatcher(time.Millisecond, fake.translate)
+
+		w.stop()
+		w.schedule(t.Context(), "post/foo.md")
+		time.Sleep(10 * time.Millisecond)
+
+		started, _ := fake.counts()
+		assert.Equal(t, 0, started)
+	})
+}
+
+func Test_translationWatcher_translate(t *testing.T) {
+	t.Run("다시 예약된 뒤 만료된 이전 타이머는 번역하지 않고 새 예약을 지우지 않음", func(t *testing.T) {
+		fake := &fakeTranslations{}
+		w := newTranslationWatcher(time.Hour, fake.translate)
+
+		w.schedule(t.Context(), "post/foo.md")
+		stale := w.timers["post/foo.md"]
+		w.schedule(t.Context(), "post/foo.md")
+		latest := w.timers["post/foo.md"]
+
+		w.translate(t.Context(), "post/foo.md", stale)
+
+		started, _ := fake.counts()
+		assert.Equal(t, 0, started)
+		assert.Same(t, latest, w.timers["post/foo.md"])
+
+		w.translate(t.Context(), "post/foo.md", latest)
+
+		started, _ = fake.counts()
+		assert.Equal(t, 1, started)
+		assert.NotContains(t, w.timers, "post/foo.md")
+		w.stop()
+	})
+
+	t.Run("진행 중인 번역이 있으면 취소하고 번역", func(t *testing.T) {
+		fake := &fakeTranslations{}
+		w := newTranslationWatcher(time.Hour, fake.translate)
+
+		ctx, cancel := context.WithCancel(t.Context())
+		defer cancel()
+
+		pending := &pendingTranslation{}
+		w.timers["post/foo.md"] = pending
+		w.running["post/foo.md"] = &runningTranslation{cancel: cancel}
+
+		w.translate(t.Context(), "post/foo.md", pending)
+
+		assert.ErrorIs(t, ctx.Err(), context.Canceled)
+		started, _ := fake.counts()
+		assert.Equal(t, 1, started)
+		w.stop()
+	})
+}
+
+func Test_isHiddenPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		relPath string
+		want    bool
+	}{
+		{
+			name:    "원본 파일",
+			relPath: "post/foo.md",
+			want:    false,
+		},
+		{
+			name:    "content 디렉터리",
+			relPath: ".",
+			want:    false,
+		},
+		{
+			name:    "숨김 디렉터리",
+			relPath: ".hugo-ai-translator",
+			want:    true,
+		},
+		{
+			name:    "숨김 디렉터리 안의 파일",
+			relPath: ".hugo-ai-translator/sources/abc",
+			want:    true,
+		},
+		{
+			name:    "저장 중인 임시 파일",
+			relPath: "post/.foo.md.tmp-1",
+			want:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, isHiddenPath(tt.relPath))
+		})
+	}
+}
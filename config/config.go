@@ -1,9 +1,13 @@
 package config
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
 	"github.com/openai/openai-go"
 	"github.com/pkg/errors"
 	"github.com/samber/lo"
@@ -59,7 +63,13 @@ const (
 )
 
 const (
-	SimpleTargetPathRule = "{origin}/{fileName}.{language}.md"
+	SimpleTargetPathRule = "{origin}/{fileName}.{language}.{ext}"
+)
+
+const (
+	// FileMode, DirMode는 API Key가 포함된 설정 파일과 그 디렉터리의 모드로, 소유자만 접근할 수 있습니다.
+	FileMode os.FileMode = 0o600
+	DirMode  os.FileMode = 0o700
 )
 
 type LanguageMap map[LanguageCode]Language
@@ -88,22 +98,175 @@ var (
 type TranslatorSourceConfig struct {
 	SourceLanguage LanguageCode `yaml:"source_language"`
 	IgnoreRules    []string     `yaml:"ignore_rules"`
+	Extensions     []string     `yaml:"extensions,omitempty"`
+	// WriteTranslationKey가 true이면 front matter에 translationKey가 없는 원본 파일에 번역 결과물과 같은 translationKey를 기록합니다.
+	WriteTranslationKey bool `yaml:"write_translation_key,omitempty"`
 }
 
 type TranslatorTargetConfig struct {
 	TargetLanguages LanguageCodes `yaml:"target_languages"`
 	TargetPathRule  string        `yaml:"target_path_rule"`
+	// FileMode, DirMode는 새로 만드는 번역 결과물과 디렉터리의 모드이며, 0이면 기본 모드(0644, 0755)를 사용합니다.
+	// 이미 있는 번역 결과물은 기존 모드를 유지합니다.
+	FileMode Mode `yaml:"file_mode,omitempty"`
+	DirMode  Mode `yaml:"dir_mode,omitempty"`
+}
+
+// Mode는 설정 파일에 8진수(ex. 0644, "0o640")로 지정하는 파일 모드입니다.
+type Mode os.FileMode
+
+func (m *Mode) UnmarshalYAML(value *yaml.Node) error {
+	digits := strings.TrimPrefix(strings.TrimPrefix(value.Value, "0o"), "0O")
+
+	mode, err := strconv.ParseUint(digits, 8, 32)
+	if err != nil || mode > 0o777 {
+		return errors.Errorf("invalid file mode %q (must be an octal permission such as 0644)", value.Value)
+	}
+	*m = Mode(mode)
+
+	return nil
+}
+
+func (m Mode) MarshalYAML() (interface{}, error) {
+	return fmt.Sprintf("%#o", uint32(m)), nil
+}
+
+// DefaultQualityThreshold는 quality.threshold를 지정하지 않았을 때 낮은 품질로 표시하는 기준 점수입니다.
+const DefaultQualityThreshold = 80
+
+// QualityConfig는 번역 결과물을 역번역하여 품질을 평가하는 설정입니다.
+type QualityConfig struct {
+	Enabled bool `yaml:"enabled"`
+	// Threshold보다 낮은 점수를 받은 번역 결과물은 실행 결과에 낮은 품질로 표시됩니다.
+	Threshold int `yaml:"threshold,omitempty"`
+}
+
+// SlugConfig는 번역 결과물의 URL slug를 번역 대상 언어로 만드는 설정입니다.
+type SlugConfig struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// InstructionsConfig는 번역 요청의 instruction에 덧붙일 지침 파일의 경로입니다.
+// 상대 경로는 설정 파일이 있는 디렉터리를 기준으로 합니다.
+type InstructionsConfig struct {
+	// Site는 모든 언어의 번역에 덧붙일 사이트 전체의 지침 파일입니다.
+	Site string `yaml:"site,omitempty"`
+	// Languages는 번역 대상 언어별로 사이트 전체의 지침 뒤에 덧붙일 지침 파일입니다. ex) 존댓말, 문장 부호 규칙
+	Languages map[LanguageCode]string `yaml:"languages,omitempty"`
+}
+
+// Read는 지침 파일을 읽어 사이트 전체의 지침과 언어별 지침을 반환합니다.
+func (c InstructionsConfig) Read() (string, map[LanguageCode]string, error) {
+	var site string
+	if c.Site != "" {
+		content, err := os.ReadFile(c.Site)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "failed to read site instruction file")
+		}
+		site = string(content)
+	}
+
+	languages := make(map[LanguageCode]string, len(c.Languages))
+	for lang, instructionPath := range c.Languages {
+		content, err := os.ReadFile(instructionPath)
+		if err != nil {
+			return "", nil, errors.Wrapf(err, "failed to read instruction file for %s", lang)
+		}
+		languages[lang] = string(content)
+	}
+
+	return site, languages, nil
+}
+
+// resolve는 지침 파일의 경로를 dir 기준 경로로 바꿉니다.
+func (c *InstructionsConfig) resolve(dir string) {
+	c.Site = resolvePath(dir, c.Site)
+	for lang, instructionPath := range c.Languages {
+		c.Languages[lang] = resolvePath(dir, instructionPath)
+	}
+}
+
+// TemplatesConfig는 번역 요청의 instruction과 prompt를 만드는 text/template 파일의 경로입니다.
+// 지정하지 않으면 기본 template을 사용하며, 상대 경로는 설정 파일이 있는 디렉터리를 기준으로 합니다.
+type TemplatesConfig struct {
+	Instruction string `yaml:"instruction,omitempty"`
+	Prompt      string `yaml:"prompt,omitempty"`
+}
+
+// Read는 template 파일을 읽어 instruction, prompt template을 반환하며, 지정하지 않은 template은 빈 문자열입니다.
+func (c TemplatesConfig) Read() (string, string, error) {
+	var contents [2]string
+	for i, templatePath := range []string{c.Instruction, c.Prompt} {
+		if templatePath == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(templatePath)
+		if err != nil {
+			return "", "", errors.Wrap(err, "failed to read template file")
+		}
+		contents[i] = string(content)
+	}
+
+	return contents[0], contents[1], nil
+}
+
+// resolvePath는 p의 ~를 홈 디렉터리로 바꾸고, 상대 경로라면 dir 기준 경로로 바꿉니다.
+func resolvePath(dir, p string) string {
+	p = replaceHomeDir(p)
+	if p == "" || filepath.IsAbs(p) {
+		return p
+	}
+
+	return filepath.Join(dir, p)
 }
 
 type TranslatorConfig struct {
 	ContentDir string                 `yaml:"content_dir"`
 	Source     TranslatorSourceConfig `yaml:"source"`
 	Target     TranslatorTargetConfig `yaml:"target"`
+	Quality    QualityConfig          `yaml:"quality,omitempty"`
+	Slug       SlugConfig             `yaml:"slug,omitempty"`
+	// Instructions는 번역 요청의 instruction에 덧붙일 지침입니다.
+	Instructions InstructionsConfig `yaml:"instructions,omitempty"`
+	// Templates는 번역 요청의 instruction과 prompt를 만드는 template입니다.
+	Templates TemplatesConfig `yaml:"templates,omitempty"`
+	// Glossary는 용어별 번역어를 정한 YAML 용어집 파일의 경로입니다.
+	Glossary string `yaml:"glossary,omitempty"`
+}
+
+// MinScore는 낮은 품질로 표시하지 않는 최소 점수를 반환하며, threshold를 지정하지 않았다면 DefaultQualityThreshold입니다.
+func (q QualityConfig) MinScore() int {
+	if q.Threshold == 0 {
+		return DefaultQualityThreshold
+	}
+
+	return q.Threshold
 }
 
 type Config struct {
 	OpenAI     OpenAIConfig     `yaml:"openai"`
 	Translator TranslatorConfig `yaml:"translator"`
+	Simple     SimpleConfig     `yaml:"-"`
+	// Sources는 이번 실행에서 번역할 원본 파일의 content_dir 기준 경로이며, nil이면 모든 원본 파일을 번역합니다.
+	Sources []string `yaml:"-"`
+	// UpdateStale이 true이면 source_hash가 현재 원본과 다른, 즉 이전 원본을 번역한 번역 결과물도 다시 번역합니다.
+	UpdateStale bool         `yaml:"-"`
+	Output      OutputConfig `yaml:"-"`
+}
+
+// OutputConfig는 번역 결과물을 저장할 위치로, 플래그로만 지정할 수 있는 설정입니다.
+type OutputConfig struct {
+	// Path는 번역 결과물을 저장할 디렉터리 또는 아카이브(.tar, .tar.gz, .tgz, .zip) 경로이며, 비어있으면 content_dir에 저장합니다.
+	Path string
+	// Diff가 true이면 번역 결과물을 저장하지 않고 기존 번역 결과물과의 diff를 출력합니다.
+	Diff bool
+}
+
+// SimpleConfig는 simple 커맨드의 플래그로만 지정할 수 있는 설정입니다.
+type SimpleConfig struct {
+	Recursive      bool
+	SkipTranslated bool
 }
 
 type OpenAIConfig struct {
@@ -137,12 +300,33 @@ func New(configPath string) (*Config, error) {
 	}
 
 	config.Translator.ContentDir = replaceHomeDir(config.Translator.ContentDir)
+	configDir := filepath.Dir(replaceHomeDir(configPath))
+	config.Translator.Instructions.resolve(configDir)
+	config.Translator.Templates.Instruction = resolvePath(configDir, config.Translator.Templates.Instruction)
+	config.Translator.Templates.Prompt = resolvePath(configDir, config.Translator.Templates.Prompt)
+	config.Translator.Glossary = resolvePath(configDir, config.Translator.Glossary)
 
 	// Set default values
 	if config.OpenAI.Model == "" {
 		config.OpenAI.Model = openai.ChatModelGPT4o
 	}
 
+	if rule := config.Translator.Target.TargetPathRule; rule != "" {
+		if _, err = pathrule.Parse(rule); err != nil {
+			return nil, errors.Wrap(err, "invalid target_path_rule in config file")
+		}
+	}
+
+	if threshold := config.Translator.Quality.Threshold; threshold < 0 || threshold > 100 {
+		return nil, errors.Errorf("invalid quality.threshold in config file: %d (must be between 0 and 100)", threshold)
+	}
+
+	for lang := range config.Translator.Instructions.Languages {
+		if _, ok := LanguageCodeToLanguage[lang]; !ok {
+			return nil, errors.Errorf("invalid language in instructions.languages in config file: %q", lang)
+		}
+	}
+
 	return &config, nil
 }
 
@@ -182,6 +366,38 @@ func bindOriginConfig(cfg *Config, originConfig *Config) {
 	if len(cfg.Translator.Target.TargetLanguages) == 0 {
 		cfg.Translator.Target.TargetLanguages = originConfig.Translator.Target.TargetLanguages
 	}
+
+	if len(cfg.Translator.Source.Extensions) == 0 {
+		cfg.Translator.Source.Extensions = originConfig.Translator.Source.Extensions
+	}
+
+	if cfg.Translator.Instructions.Site == "" && len(cfg.Translator.Instructions.Languages) == 0 {
+		cfg.Translator.Instructions = originConfig.Translator.Instructions
+	}
+
+	if cfg.Translator.Templates == (TemplatesConfig{}) {
+		cfg.Translator.Templates = originConfig.Translator.Templates
+	}
+
+	if cfg.Translator.Glossary == "" {
+		cfg.Translator.Glossary = originConfig.Translator.Glossary
+	}
+
+	if cfg.Translator.Quality == (QualityConfig{}) {
+		cfg.Translator.Quality = originConfig.Translator.Quality
+	}
+
+	if cfg.Translator.Slug == (SlugConfig{}) {
+		cfg.Translator.Slug = originConfig.Translator.Slug
+	}
+
+	if cfg.Translator.Target.FileMode == 0 {
+		cfg.Translator.Target.FileMode = originConfig.Translator.Target.FileMode
+	}
+
+	if cfg.Translator.Target.DirMode == 0 {
+		cfg.Translator.Target.DirMode = originConfig.Translator.Target.DirMode
+	}
 }
 
 func Simple(cmd *cli.Command) (*Config, error) {
@@ -192,6 +408,8 @@ func Simple(cmd *cli.Command) (*Config, error) {
 		model           = cmd.String("model")
 		sourceLanguage  = cmd.String("source-language")
 		targetLanguages = cmd.StringSlice("target-languages")
+		extensions      = cmd.StringSlice("extensions")
+		ignoreRules     = cmd.StringSlice("ignore")
 	)
 	currentDir, err := os.Getwd()
 	if err != nil {
@@ -208,21 +426,25 @@ func Simple(cmd *cli.Command) (*Config, error) {
 		}
 		cfg.Translator.Target.TargetLanguages = append(cfg.Translator.Target.TargetLanguages, LanguageCode(lang))
 	}
+	cfg.Translator.Source.Extensions = extensions
+	cfg.Translator.Source.IgnoreRules = ignoreRules
 	cfg.Translator.ContentDir = currentDir
+	cfg.Simple = SimpleConfig{
+		Recursive:      cmd.Bool("recursive"),
+		SkipTranslated: cmd.Bool("skip-translated"),
+	}
 	cfg.Translator.Target.TargetPathRule = SimpleTargetPathRule
-
-	if err = cfg.validateSimple(); err != nil && cfgPath == "" {
-		return nil, err
-	} else if err != nil {
-		var (
-			originConfig *Config
-		)
-		originConfig, err = New(cfgPath)
-		if err != nil {
+	cfg.UpdateStale = cmd.Bool("update-stale")
+
+	// 설정 파일이 있다면 명령줄에서 지정하지 않은 설정은 항상 설정 파일을 따름
+	if cfgPath != "" {
+		originConfig, err := New(cfgPath)
+		switch {
+		case err == nil:
+			bindOriginConfig(&cfg, originConfig)
+		case !errors.Is(err, os.ErrNotExist):
 			return nil, err
 		}
-
-		bindOriginConfig(&cfg, originConfig)
 	}
 
 	if err = cfg.validateSimple(); err != nil {
@@ -1,12 +1,15 @@
 package config
 
 import (
+	"context"
 	"os"
 	"path"
 	"testing"
 
 	"github.com/openai/openai-go"
 	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v3"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNew(t *testing.T) {
@@ -61,6 +64,63 @@ func TestNew(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "instructions, templates, glossary의 상대 경로는 설정 파일 기준",
+			args: args{
+				configPath: path.Join(currentDir, "test_config", "instructions_config.yaml"),
+			},
+			want: &Config{
+				OpenAI: OpenAIConfig{
+					Model:  openai.ChatModelGPT4oMini,
+					ApiKey: "test-api-key",
+				},
+				Translator: TranslatorConfig{
+					ContentDir: path.Join(homeDir, "hugo-home", "content"),
+					Source: TranslatorSourceConfig{
+						SourceLanguage: LanguageCodeKorean,
+					},
+					Target: TranslatorTargetConfig{
+						TargetLanguages: LanguageCodes{LanguageCodeGerman},
+						TargetPathRule:  "{origin}/{fileName}.{language}.md",
+					},
+					Instructions: InstructionsConfig{
+						Site: path.Join(currentDir, "test_config", "instructions", "site.md"),
+						Languages: map[LanguageCode]string{
+							LanguageCodeGerman: path.Join(currentDir, "test_config", "instructions", "de.md"),
+						},
+					},
+					Templates: TemplatesConfig{
+						Prompt: path.Join(currentDir, "test_config", "templates", "prompt.md"),
+					},
+					Glossary: path.Join(homeDir, "glossary.yaml"),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "instructions.languages에 지원하지 않는 언어가 있는 경우",
+			args: args{
+				configPath: path.Join(currentDir, "test_config", "invalid_instructions_config.yaml"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "target_path_rule이 잘못된 경우",
+			args: args{
+				configPath: path.Join(currentDir, "test_config", "invalid_rule_config.yaml"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "quality.threshold가 0~100을 벗어난 경우",
+			args: args{
+				configPath: path.Join(currentDir, "test_config", "invalid_quality_config.yaml"),
+			},
+			want:    nil,
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -70,3 +130,232 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestInstructionsConfig_Read(t *testing.T) {
+	tests := []struct {
+		name          string
+		cfg           InstructionsConfig
+		wantSite      string
+		wantLanguages map[LanguageCode]string
+		wantErr       bool
+	}{
+		{
+			name: "성공",
+			cfg: InstructionsConfig{
+				Site: "test_config/instructions/site.md",
+				Languages: map[LanguageCode]string{
+					LanguageCodeGerman: "test_config/instructions/de.md",
+				},
+			},
+			wantSite: "Keep product names in English.\n",
+			wantLanguages: map[LanguageCode]string{
+				LanguageCodeGerman: "Use the formal register (Sie).\n",
+			},
+			wantErr: false,
+		},
+		{
+			name:          "지침이 없는 경우",
+			cfg:           InstructionsConfig{},
+			wantSite:      "",
+			wantLanguages: map[LanguageCode]string{},
+			wantErr:       false,
+		},
+		{
+			name: "지침 파일이 없는 경우",
+			cfg: InstructionsConfig{
+				Languages: map[LanguageCode]string{
+					LanguageCodeGerman: "test_config/instructions/none.md",
+				},
+			},
+			wantSite:      "",
+			wantLanguages: nil,
+			wantErr:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			site, languages, err := tt.cfg.Read()
+			assert.Equalf(t, tt.wantErr, err != nil, "Read() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.wantSite, site)
+			assert.Equal(t, tt.wantLanguages, languages)
+		})
+	}
+}
+
+func Test_bindOriginConfig(t *testing.T) {
+	origin := &Config{
+		OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4o, ApiKey: "origin-key"},
+		Translator: TranslatorConfig{
+			Target:  TranslatorTargetConfig{FileMode: 0o640, DirMode: 0o750},
+			Quality: QualityConfig{Enabled: true, Threshold: 80},
+			Slug:    SlugConfig{Enabled: true},
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		want Config
+	}{
+		{
+			name: "설정하지 않은 값은 설정 파일의 값을 사용",
+			cfg:  Config{},
+			want: Config{
+				OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4o, ApiKey: "origin-key"},
+				Translator: TranslatorConfig{
+					Target:  TranslatorTargetConfig{FileMode: 0o640, DirMode: 0o750},
+					Quality: QualityConfig{Enabled: true, Threshold: 80},
+					Slug:    SlugConfig{Enabled: true},
+				},
+			},
+		},
+		{
+			name: "플래그로 설정한 값은 유지",
+			cfg: Config{
+				OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4oMini, ApiKey: "flag-key"},
+				Translator: TranslatorConfig{
+					Target:  TranslatorTargetConfig{FileMode: 0o600},
+					Quality: QualityConfig{Enabled: true},
+				},
+			},
+			want: Config{
+				OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4oMini, ApiKey: "flag-key"},
+				Translator: TranslatorConfig{
+					Target:  TranslatorTargetConfig{FileMode: 0o600, DirMode: 0o750},
+					Quality: QualityConfig{Enabled: true},
+					Slug:    SlugConfig{Enabled: true},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bindOriginConfig(&tt.cfg, origin)
+			assert.Equal(t, tt.want, tt.cfg)
+		})
+	}
+}
+
+func TestMode_UnmarshalYAML(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Mode
+		wantErr bool
+	}{
+		{
+			name:  "8진수",
+			value: "file_mode: 0640",
+			want:  0o640,
+		},
+		{
+			name:  "0o 접두사",
+			value: `file_mode: "0o600"`,
+			want:  0o600,
+		},
+		{
+			name:    "8진수가 아닌 값",
+			value:   "file_mode: 0689",
+			wantErr: true,
+		},
+		{
+			name:    "권한 범위를 벗어난 값",
+			value:   "file_mode: 01777",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var target TranslatorTargetConfig
+			err := yaml.Unmarshal([]byte(tt.value), &target)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, target.FileMode)
+
+			out, err := yaml.Marshal(target)
+			assert.NoError(t, err)
+
+			var decoded TranslatorTargetConfig
+			assert.NoError(t, yaml.Unmarshal(out, &decoded))
+			assert.Equal(t, tt.want, decoded.FileMode)
+		})
+	}
+}
+
+func TestSimple(t *testing.T) {
+	cfgPath := path.Join(t.TempDir(), "config.yaml")
+	err := os.WriteFile(cfgPath, []byte("openai:\n  model: gpt-4o\n  api_key: origin-key\ntranslator:\n  quality:\n    enabled: true\n    threshold: 80\n  slug:\n    enabled: true\n  glossary: glossary.yaml\n"), 0o644)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    *Config
+		wantErr bool
+	}{
+		{
+			name: "모든 플래그를 지정해도 설정 파일의 나머지 설정을 사용",
+			args: []string{"--config", cfgPath, "--api-key", "flag-key", "--model", "gpt-4o-mini", "--source-language", "ko", "--target-languages", "en"},
+			want: &Config{
+				OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4oMini, ApiKey: "flag-key"},
+				Translator: TranslatorConfig{
+					Quality:  QualityConfig{Enabled: true, Threshold: 80},
+					Slug:     SlugConfig{Enabled: true},
+					Glossary: path.Join(path.Dir(cfgPath), "glossary.yaml"),
+				},
+			},
+		},
+		{
+			name: "설정 파일이 없으면 플래그만 사용",
+			args: []string{"--config", path.Join(t.TempDir(), "missing.yaml"), "--api-key", "flag-key", "--model", "gpt-4o-mini", "--source-language", "ko", "--target-languages", "en"},
+			want: &Config{
+				OpenAI: OpenAIConfig{Model: openai.ChatModelGPT4oMini, ApiKey: "flag-key"},
+			},
+		},
+		{
+			name:    "설정 파일이 없고 플래그도 부족한 경우",
+			args:    []string{"--config", path.Join(t.TempDir(), "missing.yaml"), "--source-language", "ko"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got *Config
+			cmd := &cli.Command{
+				Name: "simple",
+				Flags: []cli.Flag{
+					&cli.StringFlag{Name: "config"},
+					&cli.StringFlag{Name: "api-key"},
+					&cli.StringFlag{Name: "model"},
+					&cli.StringFlag{Name: "source-language"},
+					&cli.StringSliceFlag{Name: "target-languages"},
+					&cli.StringSliceFlag{Name: "extensions"},
+					&cli.StringSliceFlag{Name: "ignore"},
+					&cli.BoolFlag{Name: "recursive"},
+					&cli.BoolFlag{Name: "skip-translated"},
+					&cli.BoolFlag{Name: "update-stale"},
+				},
+				Action: func(_ context.Context, cmd *cli.Command) error {
+					var err error
+					got, err = Simple(cmd)
+					return err
+				},
+			}
+
+			err := cmd.Run(t.Context(), append([]string{"simple"}, tt.args...))
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+
+			assert.Equal(t, tt.want.OpenAI, got.OpenAI)
+			assert.Equal(t, tt.want.Translator.Quality, got.Translator.Quality)
+			assert.Equal(t, tt.want.Translator.Slug, got.Translator.Slug)
+			assert.Equal(t, tt.want.Translator.Glossary, got.Translator.Glossary)
+		})
+	}
+}
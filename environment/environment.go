@@ -1,42 +1,110 @@
 package environment
 
 import (
+	"os"
+
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
 	"github.com/YangTaeyoung/hugo-ai-translator/file"
 	"github.com/YangTaeyoung/hugo-ai-translator/llm"
 	"github.com/YangTaeyoung/hugo-ai-translator/translator"
 	"github.com/openai/openai-go"
 	"github.com/openai/openai-go/option"
+	"github.com/pkg/errors"
 )
 
 type Environment struct {
 	Translator translator.Translator
-	Parser     file.Parser
-	Writer     file.Writer
+	// Estimator는 quality.enabled일 때만 설정되며, 그렇지 않으면 nil입니다.
+	Estimator translator.Estimator
+	// Slugger는 slug.enabled일 때만 설정되며, 그렇지 않으면 nil입니다.
+	Slugger translator.Slugger
+	Parser  file.Parser
+	Writer  file.Writer
 }
 
-func New(cfg *config.Config) *Environment {
+func New(cfg *config.Config) (*Environment, error) {
 	var env Environment
 
 	openaiClient := llm.NewOpenAIClient(openai.NewClient(option.WithAPIKey(cfg.OpenAI.ApiKey)))
 
-	env.Translator = translator.New(openaiClient, translator.Config{
-		SourceLanguage:  cfg.Translator.Source.SourceLanguage,
-		TargetLanguages: cfg.Translator.Target.TargetLanguages,
-		Model:           cfg.OpenAI.Model,
-	})
+	siteInstruction, languageInstructions, err := cfg.Translator.Instructions.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	instructionTemplate, promptTemplate, err := cfg.Translator.Templates.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	// 잘못된 template은 번역을 시작하기 전에 알림
+	templates, err := translator.ParseTemplates(instructionTemplate, promptTemplate)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load templates in config file")
+	}
+
+	var glossary translator.Glossary
+	if cfg.Translator.Glossary != "" {
+		if glossary, err = translator.ReadGlossary(cfg.Translator.Glossary); err != nil {
+			return nil, err
+		}
+	}
+
+	translatorCfg := translator.Config{
+		SourceLanguage:       cfg.Translator.Source.SourceLanguage,
+		TargetLanguages:      cfg.Translator.Target.TargetLanguages,
+		Model:                cfg.OpenAI.Model,
+		SiteInstruction:      siteInstruction,
+		LanguageInstructions: languageInstructions,
+		Templates:            templates,
+		Glossary:             glossary,
+	}
+
+	env.Translator = translator.New(openaiClient, translatorCfg)
+	if cfg.Translator.Quality.Enabled {
+		env.Estimator = translator.NewEstimator(openaiClient, translatorCfg)
+	}
+	if cfg.Translator.Slug.Enabled {
+		env.Slugger = translator.NewSlugger(openaiClient, translatorCfg)
+	}
 	env.Parser = file.NewParser(file.ParserConfig{
 		ContentDir:      cfg.Translator.ContentDir,
 		TargetLanguages: cfg.Translator.Target.TargetLanguages,
 		TargetPathRule:  cfg.Translator.Target.TargetPathRule,
 		IgnoreRules:     cfg.Translator.Source.IgnoreRules,
 		SourceLanguage:  cfg.Translator.Source.SourceLanguage,
+		Extensions:      cfg.Translator.Source.Extensions,
+		Recursive:       cfg.Simple.Recursive,
+		SkipTranslated:  cfg.Simple.SkipTranslated,
+		Sources:         cfg.Sources,
+		UpdateStale:     cfg.UpdateStale,
 	})
 
-	env.Writer = file.NewWriter(file.WriterConfig{
-		ContentDir:     cfg.Translator.ContentDir,
-		TargetPathRule: cfg.Translator.Target.TargetPathRule,
-	})
+	var (
+		fileMode = os.FileMode(cfg.Translator.Target.FileMode)
+		dirMode  = os.FileMode(cfg.Translator.Target.DirMode)
+	)
+	sink, err := file.NewSink(cfg.Translator.ContentDir, cfg.Output.Path, fileMode, dirMode)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Output.Diff {
+		sink = file.NewDiffSink(cfg.Translator.ContentDir, os.Stdout)
+	}
+
+	writerCfg := file.WriterConfig{
+		ContentDir:                cfg.Translator.ContentDir,
+		TargetPathRule:            cfg.Translator.Target.TargetPathRule,
+		Sink:                      sink,
+		FileMode:                  fileMode,
+		DirMode:                   dirMode,
+		WriteSourceTranslationKey: cfg.Translator.Source.WriteTranslationKey,
+	}
+	// 원본은 번역 결과물이 content 디렉터리에 저장되어 다음 실행에서 이전 번역 결과물로 읽힐 때만 보관
+	if cfg.Output.Path == "" && !cfg.Output.Diff {
+		writerCfg.Snapshots = file.NewSourceSnapshots(cfg.Translator.ContentDir)
+	}
+	env.Writer = file.NewWriter(writerCfg)
 
-	return &env
+	return &env, nil
 }
@@ -0,0 +1,169 @@
+package file
+
+import (
+	"slices"
+	"strings"
+)
+
+// Paragraphs는 content를 front matter와 빈 줄로 구분된 문단으로 나눕니다.
+// front matter와 code block 안의 빈 줄은 문단을 나누지 않으며, 문단 앞뒤의 빈 줄은 포함하지 않습니다.
+func Paragraphs(content Markdown) []string {
+	var (
+		lines      = strings.Split(content.String(), "\n")
+		paragraphs []string
+		current    []string
+		fence      string
+	)
+
+	flush := func() {
+		if len(current) > 0 {
+			paragraphs = append(paragraphs, strings.Join(current, "\n"))
+			current = nil
+		}
+	}
+
+	// front matter는 닫는 구분자까지 하나의 문단
+	if len(lines) > 0 && (lines[0] == "---" || lines[0] == "+++") {
+		for i := 1; i < len(lines); i++ {
+			if lines[i] == lines[0] {
+				paragraphs = append(paragraphs, strings.Join(lines[:i+1], "\n"))
+				lines = lines[i+1:]
+				break
+			}
+		}
+	}
+
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			fence = "```"
+		case strings.HasPrefix(trimmed, "~~~"):
+			fence = "~~~"
+		case trimmed == "":
+			flush()
+			continue
+		}
+
+		current = append(current, line)
+	}
+	flush()
+
+	return paragraphs
+}
+
+// JoinParagraphs는 문단을 빈 줄 하나로 이어 붙이며, newline이 true이면 마지막에 줄바꿈을 붙입니다.
+func JoinParagraphs(paragraphs []string, newline bool) Markdown {
+	joined := strings.Join(paragraphs, "\n\n")
+	if newline && joined != "" {
+		joined += "\n"
+	}
+
+	return Markdown(joined)
+}
+
+// AlignedParagraph는 원본의 문단 하나와, 바뀌지 않은 문단이라면 그 문단의 이전 번역입니다.
+type AlignedParagraph struct {
+	Source     string
+	Translated string
+	// Aligned는 이전 원본에 같은 문단이 있어 Translated를 그대로 사용할 수 있는지 여부입니다.
+	Aligned bool
+}
+
+// Alignment는 이전 원본의 문단과 이전 번역 결과물의 문단을 위치로 짝지어, 원본 문단의 hash별로 번역을 보관합니다.
+type Alignment struct {
+	// translations는 원본 문단의 hash별로 이전 번역을 원본에 나타난 순서대로 보관
+	translations map[string][]string
+}
+
+// Align은 이전 원본 source와 그 번역 결과물 translated의 문단을 위치로 짝지은 Alignment를 만듭니다.
+// translated에서 writer가 front matter에 기록한 key는 제외하고 짝지으며, 이 key는 번역 결과물을 저장할 때 다시 기록됩니다.
+// 문단 수가 다르면 위치로 짝지을 수 없으므로 false를 반환합니다.
+func Align(source, translated Markdown) (*Alignment, bool) {
+	var (
+		sourceParagraphs     = Paragraphs(source)
+		translatedParagraphs = Paragraphs(withoutWriterFrontMatter(source, translated))
+	)
+	if len(sourceParagraphs) == 0 || len(sourceParagraphs) != len(translatedParagraphs) {
+		return nil, false
+	}
+
+	a := &Alignment{
+		translations: make(map[string][]string, len(sourceParagraphs)),
+	}
+	for i, paragraph := range sourceParagraphs {
+		hash := SourceHash(Markdown(paragraph))
+		a.translations[hash] = append(a.translations[hash], translatedParagraphs[i])
+	}
+
+	return a, true
+}
+
+// Plan은 source의 문단마다 이전 원본에 같은 문단이 있다면 그 번역을 짝지어 반환합니다.
+// 같은 문단이 여러 번 나타나면 나타난 순서대로 짝지으며, 이전 원본보다 많이 나타나면 마지막 번역을 다시 사용합니다.
+func (a *Alignment) Plan(source Markdown) []AlignedParagraph {
+	var (
+		paragraphs = Paragraphs(source)
+		plan       = make([]AlignedParagraph, 0, len(paragraphs))
+		used       = make(map[string]int)
+	)
+	for _, paragraph := range paragraphs {
+		aligned := AlignedParagraph{Source: paragraph}
+
+		hash := SourceHash(Markdown(paragraph))
+		if translations := a.translations[hash]; len(translations) > 0 {
+			aligned.Translated = translations[min(used[hash], len(translations)-1)]
+			aligned.Aligned = true
+			used[hash]++
+		}
+
+		plan = append(plan, aligned)
+	}
+
+	return plan
+}
+
+// withoutWriterFrontMatter는 번역 결과물 translated의 YAML, TOML front matter에서 writer가 기록한 key를 제거합니다.
+// 원본 source의 front matter에 있는 key는 작성자가 기록한 값이므로 그대로 두며,
+// source에 같은 형식의 front matter가 없다면 translated의 front matter는 writer가 만든 것이므로 통째로 제거합니다.
+func withoutWriterFrontMatter(source, translated Markdown) Markdown {
+	lines := strings.Split(translated.String(), "\n")
+	if len(lines) == 0 || (lines[0] != "---" && lines[0] != "+++") {
+		return translated
+	}
+
+	delimiter := lines[0]
+	end := slices.Index(lines[1:], delimiter) + 1
+	if end == 0 {
+		return translated
+	}
+
+	if !strings.HasPrefix(source.String(), delimiter+"\n") {
+		return Markdown(strings.TrimLeft(strings.Join(lines[end+1:], "\n"), "\n"))
+	}
+
+	var sourceFrontMatter map[string]any
+	if err := parseFrontMatter([]byte(source), &sourceFrontMatter); err != nil {
+		return translated
+	}
+
+	frontMatter := slices.DeleteFunc(slices.Clone(lines[1:end]), func(line string) bool {
+		// writer가 기록하는 값은 모두 한 줄이므로 최상위 key의 줄만 제거
+		i := strings.IndexAny(line, ":=")
+		if i < 0 || strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t") {
+			return false
+		}
+
+		key := strings.TrimSpace(line[:i])
+		_, authored := sourceFrontMatter[key]
+
+		return slices.Contains(writerFrontMatterKeys, key) && !authored
+	})
+
+	return Markdown(strings.Join(slices.Concat(lines[:1], frontMatter, lines[end:]), "\n"))
+}
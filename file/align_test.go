@@ -0,0 +1,197 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParagraphs(t *testing.T) {
+	tests := []struct {
+		name    string
+		content Markdown
+		want    []string
+	}{
+		{
+			name:    "빈 줄로 문단 구분",
+			content: "# 안녕\n\n첫 문단\n이어지는 줄\n\n\n둘째 문단\n",
+			want:    []string{"# 안녕", "첫 문단\n이어지는 줄", "둘째 문단"},
+		},
+		{
+			name:    "front matter는 하나의 문단",
+			content: "---\ntitle: 안녕\n\ntags: [a]\n---\n# 안녕",
+			want:    []string{"---\ntitle: 안녕\n\ntags: [a]\n---", "# 안녕"},
+		},
+		{
+			name:    "code block 안의 빈 줄은 문단을 나누지 않음",
+			content: "코드\n\n```go\nfunc a() {}\n\nfunc b() {}\n```\n\n끝",
+			want:    []string{"코드", "```go\nfunc a() {}\n\nfunc b() {}\n```", "끝"},
+		},
+		{
+			name:    "빈 컨텐츠",
+			content: "",
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Paragraphs(tt.content))
+		})
+	}
+}
+
+func TestAlignment_Plan(t *testing.T) {
+	tests := []struct {
+		name       string
+		previous   Markdown
+		translated Markdown
+		source     Markdown
+		want       []AlignedParagraph
+		wantOK     bool
+	}{
+		{
+			name:       "바뀐 문단만 짝짓지 않음",
+			previous:   "# 안녕\n\n첫 문단\n\n둘째 문단",
+			translated: "# Hello\n\nFirst\n\nSecond",
+			source:     "# 안녕\n\n새 문단\n\n둘째 문단",
+			want: []AlignedParagraph{
+				{Source: "# 안녕", Translated: "# Hello", Aligned: true},
+				{Source: "새 문단"},
+				{Source: "둘째 문단", Translated: "Second", Aligned: true},
+			},
+			wantOK: true,
+		},
+		{
+			name:       "옮긴 문단과 반복된 문단도 짝지음",
+			previous:   "가\n\n나\n\n가",
+			translated: "A1\n\nB\n\nA2",
+			source:     "나\n\n가\n\n가\n\n가",
+			want: []AlignedParagraph{
+				{Source: "나", Translated: "B", Aligned: true},
+				{Source: "가", Translated: "A1", Aligned: true},
+				{Source: "가", Translated: "A2", Aligned: true},
+				{Source: "가", Translated: "A2", Aligned: true},
+			},
+			wantOK: true,
+		},
+		{
+			name:       "문단 수가 다르면 짝지을 수 없음",
+			previous:   "가\n\n나",
+			translated: "A B",
+			source:     "가",
+			wantOK:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			alignment, ok := Align(tt.previous, tt.translated)
+			assert.Equal(t, tt.wantOK, ok)
+			if ok {
+				assert.Equal(t, tt.want, alignment.Plan(tt.source))
+			}
+		})
+	}
+}
+
+func TestJoinParagraphs(t *testing.T) {
+	assert.Equal(t, Markdown("가\n\n나\n"), JoinParagraphs([]string{"가", "나"}, true))
+	assert.Equal(t, Markdown("가\n\n나"), JoinParagraphs([]string{"가", "나"}, false))
+	assert.Equal(t, Markdown(""), JoinParagraphs(nil, true))
+}
+
+func TestAlign_WriterOutput(t *testing.T) {
+	score := 90
+
+	tests := []struct {
+		name        string
+		source      Markdown
+		frontMatter map[string]any
+		translated  Markdown
+		slug        string
+		want        []string
+	}{
+		{
+			name:       "원본에 front matter가 있는 경우 writer가 기록한 key는 제외",
+			source:     "---\ntitle: 안녕\n---\n\n# 안녕\n\n첫 문단\n",
+			translated: "---\ntitle: Hello\n---\n\n# Hello\n\nFirst paragraph\n",
+			slug:       "hello",
+			want:       []string{"---\ntitle: Hello\n---", "# Hello", "First paragraph"},
+		},
+		{
+			name:        "원본 front matter에 작성자가 기록한 key는 그대로",
+			source:      "---\ntitle: 안녕\nslug: hello\ntranslationKey: greeting\n---\n\n# 안녕\n",
+			frontMatter: map[string]any{"title": "안녕", "slug": "hello", "translationKey": "greeting"},
+			translated:  "---\ntitle: Hello\nslug: hello\ntranslationKey: greeting\n---\n\n# Hello\n",
+			want:        []string{"---\ntitle: Hello\nslug: hello\ntranslationKey: greeting\n---", "# Hello"},
+		},
+		{
+			name:       "원본에 front matter가 없는 경우 writer가 만든 front matter는 제외",
+			source:     "# 안녕\n\n첫 문단\n",
+			translated: "# Hello\n\nFirst paragraph\n",
+			want:       []string{"# Hello", "First paragraph"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+
+			w := NewWriter(WriterConfig{
+				ContentDir:     contentDir,
+				TargetPathRule: "{origin}/{fileName}.{language}.md",
+			})
+			err := w.Write(t.Context(), ContentFile{
+				SourcePath:   "post/foo.md",
+				FileName:     "foo",
+				Ext:          ".md",
+				OriginDir:    "post",
+				Language:     config.LanguageCodeEnglish,
+				Content:      tt.source,
+				Translated:   tt.translated,
+				QualityScore: &score,
+				Slug:         tt.slug,
+				FrontMatter:  tt.frontMatter,
+			})
+			assert.NoError(t, err)
+
+			previous, err := os.ReadFile(filepath.Join(contentDir, "post", "foo.en.md"))
+			assert.NoError(t, err)
+
+			alignment, ok := Align(tt.source, Markdown(previous))
+			assert.True(t, ok)
+
+			var got []string
+			for _, paragraph := range alignment.Plan(tt.source) {
+				assert.True(t, paragraph.Aligned)
+				got = append(got, paragraph.Translated)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestAlign_AuthoredFrontMatter(t *testing.T) {
+	var (
+		source     = Markdown("---\ntitle: 안녕\nslug: hello\n---\n\n# 안녕\n")
+		translated = Markdown("---\ntitle: Hello\nslug: hello\ntranslated: true\nsource_hash: abc\n---\n\n# Hello\n")
+		modified   = Markdown("---\ntitle: 안녕\nslug: hello-world\n---\n\n# 안녕\n")
+	)
+
+	alignment, ok := Align(source, translated)
+	assert.True(t, ok)
+
+	// 작성자가 원본 front matter의 slug를 바꾸면 front matter는 바뀐 문단
+	plan := alignment.Plan(modified)
+	if assert.Len(t, plan, 2) {
+		assert.False(t, plan[0].Aligned)
+		assert.True(t, plan[1].Aligned)
+	}
+
+	// 바뀌지 않았다면 writer가 기록한 key만 제외한 이전 번역을 사용
+	plan = alignment.Plan(source)
+	if assert.Len(t, plan, 2) {
+		assert.Equal(t, "---\ntitle: Hello\nslug: hello\n---", plan[0].Translated)
+	}
+}
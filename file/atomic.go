@@ -0,0 +1,65 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultFileMode는 새로 만드는 번역 결과물의 기본 파일 모드입니다.
+	DefaultFileMode os.FileMode = 0o644
+	// DefaultDirMode는 번역 결과물을 위해 새로 만드는 디렉터리의 기본 모드입니다.
+	DefaultDirMode os.FileMode = 0o755
+)
+
+// WriteFileAtomic은 같은 디렉터리의 임시 파일에 data를 쓴 뒤 path로 rename하여,
+// 쓰기가 중단되더라도 path에 일부만 쓰인 파일이 남지 않도록 합니다.
+// 파일 모드는 기존 파일과 관계없이 perm으로 설정됩니다.
+func WriteFileAtomic(path string, data []byte, perm os.FileMode) (err error) {
+	dir, base := filepath.Split(path)
+	if dir == "" {
+		dir = "."
+	}
+
+	// 임시 파일은 숨김 파일이면서 컨텐츠 확장자로 끝나지 않으므로 번역 대상으로 인식되지 않음
+	tmp, err := os.CreateTemp(dir, "."+base+".tmp-*")
+	if err != nil {
+		return errors.Wrap(err, "failed to create temporary file")
+	}
+	defer func() {
+		if err != nil {
+			_ = tmp.Close()
+			_ = os.Remove(tmp.Name())
+		}
+	}()
+
+	if _, err = tmp.Write(data); err != nil {
+		return errors.Wrap(err, "failed to write temporary file")
+	}
+	if err = tmp.Sync(); err != nil {
+		return errors.Wrap(err, "failed to sync temporary file")
+	}
+	if err = tmp.Chmod(perm); err != nil {
+		return errors.Wrap(err, "failed to change mode of temporary file")
+	}
+	if err = tmp.Close(); err != nil {
+		return errors.Wrap(err, "failed to close temporary file")
+	}
+
+	if err = os.Rename(tmp.Name(), path); err != nil {
+		return errors.Wrapf(err, "failed to rename temporary file to %s", path)
+	}
+
+	return nil
+}
+
+// fileMode는 path에 파일이 이미 있다면 그 파일의 모드를, 없다면 perm을 반환합니다.
+func fileMode(path string, perm os.FileMode) os.FileMode {
+	if info, err := os.Stat(path); err == nil {
+		return info.Mode().Perm()
+	}
+
+	return perm
+}
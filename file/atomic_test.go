@@ -0,0 +1,66 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteFileAtomic(t *testing.T) {
+	tests := []struct {
+		name     string
+		existing bool
+		perm     os.FileMode
+		wantMode os.FileMode
+		wantErr  bool
+	}{
+		{
+			name:     "새 파일",
+			existing: false,
+			perm:     0o644,
+			wantMode: 0o644,
+			wantErr:  false,
+		},
+		{
+			name:     "기존 파일의 모드와 관계없이 perm으로 설정",
+			existing: true,
+			perm:     0o600,
+			wantMode: 0o600,
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, "config.yaml")
+			if tt.existing {
+				if err := os.WriteFile(path, []byte("old"), 0o777); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			err := WriteFileAtomic(path, []byte("new"), tt.perm)
+			assert.Equalf(t, tt.wantErr, err != nil, "WriteFileAtomic() error = %v, wantErr %v", err, tt.wantErr)
+
+			content, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Equal(t, "new", string(content))
+
+			info, err := os.Stat(path)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMode, info.Mode().Perm())
+
+			// 임시 파일이 남아있지 않아야 함
+			entries, err := os.ReadDir(dir)
+			assert.NoError(t, err)
+			assert.Len(t, entries, 1)
+		})
+	}
+}
+
+func TestWriteFileAtomic_NotExistDir(t *testing.T) {
+	err := WriteFileAtomic(filepath.Join(t.TempDir(), "none", "a.md"), []byte("new"), 0o644)
+	assert.Error(t, err)
+}
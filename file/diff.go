@@ -0,0 +1,112 @@
+package file
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+type targetDiff struct {
+	name string
+	diff string
+}
+
+// diffSink는 번역 결과물을 쓰는 대신 contentDir의 기존 번역 결과물과 비교한 unified diff를 모았다가,
+// Close 시 번역 순서와 관계없이 경로 순으로 out에 출력합니다.
+type diffSink struct {
+	contentDir string
+	out        io.Writer
+
+	mu    sync.Mutex
+	diffs []targetDiff
+}
+
+func NewDiffSink(contentDir string, out io.Writer) Sink {
+	return &diffSink{
+		contentDir: contentDir,
+		out:        out,
+	}
+}
+
+// WriteFile은 기존 번역 결과물이 없다면 모든 줄이 추가된 diff를, 달라진 내용이 없다면 빈 diff를 기록합니다.
+func (s *diffSink) WriteFile(name string, data []byte) error {
+	fromFile := "a/" + filepath.ToSlash(name)
+	existing, err := os.ReadFile(filepath.Join(s.contentDir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		fromFile = "/dev/null"
+	} else if err != nil {
+		return errors.Wrap(err, "failed to read existing translated content")
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(string(existing)),
+		B:        splitLines(string(data)),
+		FromFile: fromFile,
+		ToFile:   "b/" + filepath.ToSlash(name),
+		Context:  3,
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to diff %s", name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.diffs = append(s.diffs, targetDiff{name: name, diff: diff})
+
+	return nil
+}
+
+// Remove는 diff만 출력하므로 아무것도 삭제하지 않습니다.
+func (s *diffSink) Remove(string) error {
+	return nil
+}
+
+func (s *diffSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slices.SortFunc(s.diffs, func(a, b targetDiff) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	var changed int
+	for _, d := range s.diffs {
+		if d.diff == "" {
+			continue
+		}
+
+		changed++
+		if _, err := fmt.Fprint(s.out, d.diff); err != nil {
+			return errors.Wrap(err, "failed to print diff")
+		}
+	}
+
+	_, err := fmt.Fprintf(s.out, "\n%d of %d translations changed\n", changed, len(s.diffs))
+
+	return errors.Wrap(err, "failed to print diff")
+}
+
+// splitLines는 s를 줄바꿈을 포함한 줄 단위로 나눕니다.
+// difflib.SplitLines와 달리 마지막 줄바꿈 뒤에 빈 줄을 추가하지 않으며, 줄바꿈이 없는 마지막 줄에는 줄바꿈을 붙입니다.
+func splitLines(s string) []string {
+	if s == "" {
+		return nil
+	}
+
+	lines := strings.SplitAfter(s, "\n")
+	if last := len(lines) - 1; lines[last] == "" {
+		lines = lines[:last]
+	} else {
+		lines[last] += "\n"
+	}
+
+	return lines
+}
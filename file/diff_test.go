@@ -0,0 +1,95 @@
+package file
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_diffSink(t *testing.T) {
+	const sourceHash = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+
+	tests := []struct {
+		name     string
+		existing string
+		want     string
+		wantErr  bool
+	}{
+		{
+			name:     "기존 번역 결과물이 없음",
+			existing: "",
+			want: "--- /dev/null\n" +
+				"+++ b/post/test.ko.md\n" +
+				"@@ -0,0 +1,6 @@\n" +
+				"+---\n" +
+				"+source_hash: " + sourceHash + "\n" +
+				"+translated: true\n" +
+				"+translationKey: post/test\n" +
+				"+---\n" +
+				"+# 안녕하세요\n" +
+				"\n" +
+				"1 of 1 translations changed\n",
+			wantErr: false,
+		},
+		{
+			name:     "기존 번역 결과물과 다름",
+			existing: "---\nsource_hash: " + sourceHash + "\ntranslated: true\ntranslationKey: post/test\n---\n# 안녕\n",
+			want: "--- a/post/test.ko.md\n" +
+				"+++ b/post/test.ko.md\n" +
+				"@@ -3,4 +3,4 @@\n" +
+				" translated: true\n" +
+				" translationKey: post/test\n" +
+				" ---\n" +
+				"-# 안녕\n" +
+				"+# 안녕하세요\n" +
+				"\n" +
+				"1 of 1 translations changed\n",
+			wantErr: false,
+		},
+		{
+			name:     "기존 번역 결과물과 같음",
+			existing: "---\nsource_hash: " + sourceHash + "\ntranslated: true\ntranslationKey: post/test\n---\n# 안녕하세요\n",
+			want:     "\n0 of 1 translations changed\n",
+			wantErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+			if tt.existing != "" {
+				if err := os.MkdirAll(filepath.Join(contentDir, "post"), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(filepath.Join(contentDir, "post", "test.ko.md"), []byte(tt.existing), 0o644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			var out bytes.Buffer
+			w := writer{
+				cfg: WriterConfig{
+					ContentDir:     contentDir,
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					Sink:           NewDiffSink(contentDir, &out),
+				},
+			}
+			err := w.Write(t.Context(), ContentFile{
+				FileName:   "test",
+				OriginDir:  "post",
+				Language:   config.LanguageCodeKorean,
+				Translated: "# 안녕하세요\n",
+			})
+			assert.Equalf(t, tt.wantErr, err != nil, "Write() error = %v, wantErr %v", err, tt.wantErr)
+			assert.NoError(t, w.Close())
+			assert.Equal(t, tt.want, out.String())
+
+			// 번역 결과물을 쓰지 않아야 함
+			_, err = os.Stat(filepath.Join(contentDir, "post", "test.ko.md"))
+			assert.Equal(t, tt.existing == "", os.IsNotExist(err))
+		})
+	}
+}
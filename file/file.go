@@ -1,10 +1,12 @@
 package file
 
 import (
-	"path"
+	"crypto/sha256"
+	"encoding/hex"
 	"path/filepath"
 	"strings"
 
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
 	"github.com/pkg/errors"
 )
 
@@ -12,34 +14,14 @@ var (
 	ErrEmptyPath = errors.New("empty path")
 )
 
-func TargetFileContentPath(contentDir string,
-	targetFilePathRule string,
-	origin string,
-	language string,
-	fileName string,
-) string {
-	replacer := strings.NewReplacer(
-		"{origin}", origin,
-		"{language}", language,
-		"{fileName}", fileName,
-	)
-
-	return path.Join(contentDir, replacer.Replace(targetFilePathRule))
-}
+// TargetFilePath는 target path rule에 따라 번역 결과물이 저장될 contentDir 기준 상대 경로를 반환합니다.
+func TargetFilePath(targetFilePathRule string, file ContentFile) (string, error) {
+	rule, err := pathrule.Parse(targetFilePathRule)
+	if err != nil {
+		return "", err
+	}
 
-func TargetFilePath(
-	targetFilePathRule string,
-	origin string,
-	language string,
-	fileName string,
-) string {
-	replacer := strings.NewReplacer(
-		"{origin}", origin,
-		"{language}", language,
-		"{fileName}", fileName,
-	)
-
-	return replacer.Replace(targetFilePathRule)
+	return rule.Render(file.PathVars())
 }
 
 func FileNameWithoutExtension(path string) (string, error) {
@@ -55,3 +37,11 @@ func FileNameWithoutExtension(path string) (string, error) {
 
 	return fileName, nil
 }
+
+// SourceHash는 원본 컨텐츠의 sha256 해시를 hex 문자열로 반환합니다.
+// 번역 결과물의 front matter에 source_hash로 기록되어, 이름이 바뀐 원본을 찾는 데 사용됩니다.
+func SourceHash(content Markdown) string {
+	sum := sha256.Sum256([]byte(content))
+
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,81 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTargetFilePath(t *testing.T) {
+	type args struct {
+		targetFilePathRule string
+		file               ContentFile
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "같은 디렉터리에 언어 접미사로 저장하는 경우",
+			args: args{
+				targetFilePathRule: "{origin}/{fileName}.{language}.md",
+				file: ContentFile{
+					OriginDir: "post",
+					FileName:  "hello",
+					Ext:       ".md",
+					Language:  config.LanguageCodeEnglish,
+				},
+			},
+			want: "post/hello.en.md",
+		},
+		{
+			name: "최상위 디렉터리의 확장자 유지",
+			args: args{
+				targetFilePathRule: "{origin}/{filename}.{language}.{ext}",
+				file: ContentFile{
+					OriginDir: ".",
+					FileName:  "hello",
+					Ext:       ".html",
+					Language:  config.LanguageCodeJapanese,
+				},
+			},
+			want: "hello.ja.html",
+		},
+		{
+			name: "front matter의 slug 사용",
+			args: args{
+				targetFilePathRule: "{language}/{section}/{if slug}{slug}{else}{fileName}{end}.md",
+				file: ContentFile{
+					OriginDir:   "post/2025",
+					FileName:    "hello",
+					Ext:         ".md",
+					Language:    config.LanguageCodeEnglish,
+					FrontMatter: map[string]any{"slug": "hi"},
+				},
+			},
+			want: "en/post/hi.md",
+		},
+		{
+			name: "잘못된 rule",
+			args: args{
+				targetFilePathRule: "{origin}/{fileName}.md",
+				file: ContentFile{
+					OriginDir: "post",
+					FileName:  "hello",
+					Language:  config.LanguageCodeEnglish,
+				},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TargetFilePath(tt.args.targetFilePathRule, tt.args.file)
+			assert.Equalf(t, tt.wantErr, err != nil, "TargetFilePath() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
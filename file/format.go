@@ -0,0 +1,94 @@
+package file
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Format은 Hugo가 렌더링할 수 있는 컨텐츠 파일의 형식입니다.
+type Format string
+
+const (
+	FormatMarkdown Format = "markdown"
+	FormatHTML     Format = "html"
+	FormatAsciiDoc Format = "asciidoc"
+	FormatOrg      Format = "org"
+	FormatRST      Format = "rst"
+)
+
+func (f Format) String() string {
+	return string(f)
+}
+
+// Name은 프롬프트에 노출할 사람이 읽기 좋은 형식 이름을 반환합니다.
+func (f Format) Name() string {
+	switch f {
+	case FormatHTML:
+		return "HTML"
+	case FormatAsciiDoc:
+		return "AsciiDoc"
+	case FormatOrg:
+		return "Org"
+	case FormatRST:
+		return "reStructuredText"
+	default:
+		return "Markdown"
+	}
+}
+
+// DefaultExtensions는 설정에 확장자 목록이 없을 때 번역 대상으로 삼는 확장자입니다.
+var DefaultExtensions = []string{".md"}
+
+// extensionToFormat은 Hugo가 인식하는 컨텐츠 확장자와 형식의 매핑입니다.
+// https://gohugo.io/content-management/formats/#classification
+var extensionToFormat = map[string]Format{
+	".md":       FormatMarkdown,
+	".markdown": FormatMarkdown,
+	".mdown":    FormatMarkdown,
+	".html":     FormatHTML,
+	".htm":      FormatHTML,
+	".adoc":     FormatAsciiDoc,
+	".asciidoc": FormatAsciiDoc,
+	".ad":       FormatAsciiDoc,
+	".org":      FormatOrg,
+	".rst":      FormatRST,
+}
+
+// NormalizeExtension은 "md", ".MD" 같은 입력을 ".md" 형태로 정규화합니다.
+func NormalizeExtension(ext string) string {
+	ext = strings.ToLower(strings.TrimSpace(ext))
+	if ext == "" {
+		return ""
+	}
+
+	if !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	return ext
+}
+
+// FormatFromExtension은 확장자에 해당하는 형식을 반환합니다.
+func FormatFromExtension(ext string) (Format, bool) {
+	format, ok := extensionToFormat[NormalizeExtension(ext)]
+
+	return format, ok
+}
+
+// hasExtension은 path의 확장자가 extensions 중 하나이면서 지원하는 형식인지 확인합니다.
+// extensions가 비어있으면 DefaultExtensions를 사용합니다.
+func hasExtension(path string, extensions []string) bool {
+	if len(extensions) == 0 {
+		extensions = DefaultExtensions
+	}
+
+	ext := NormalizeExtension(filepath.Ext(path))
+	if _, ok := extensionToFormat[ext]; !ok {
+		return false
+	}
+
+	return slices.ContainsFunc(extensions, func(e string) bool {
+		return NormalizeExtension(e) == ext
+	})
+}
@@ -1,16 +1,260 @@
 package file
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
-	"os"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 
+	"github.com/adrg/frontmatter"
 	"github.com/pkg/errors"
 	"gopkg.in/yaml.v3"
 )
 
+// orgKeywordPrefix는 Org 형식 front matter의 각 줄이 시작하는 접두사입니다.
+const orgKeywordPrefix = "#+"
+
+var orgKeywordPattern = regexp.MustCompile(`^#\+([\w-]+):[ \t]*(.*)$`)
+
+// orgFrontmatterLines는 content 맨 앞의 연속된 Org keyword 줄 수를 반환합니다.
+func orgFrontmatterLines(lines []string) int {
+	for i, line := range lines {
+		if !orgKeywordPattern.MatchString(line) {
+			return i
+		}
+	}
+
+	return len(lines)
+}
+
+// parseOrgFrontmatter는 Org 형식의 front matter를 key-value map으로 파싱합니다.
+// 값은 YAML 스칼라로 해석하여 true, 숫자 등의 타입을 보존합니다.
+func parseOrgFrontmatter(content string) map[string]interface{} {
+	var (
+		lines  = strings.Split(content, "\n")
+		values = make(map[string]interface{})
+	)
+	for _, line := range lines[:orgFrontmatterLines(lines)] {
+		groups := orgKeywordPattern.FindStringSubmatch(line)
+
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(groups[2]), &value); err != nil || value == nil {
+			value = groups[2]
+		}
+		values[strings.ToLower(groups[1])] = value
+	}
+
+	return values
+}
+
+// updateOrgFrontmatter는 Org 형식의 front matter에서 updates의 key를 업데이트(또는 추가)합니다.
+func updateOrgFrontmatter(content string, updates map[string]interface{}) string {
+	var (
+		lines   = strings.Split(content, "\n")
+		n       = orgFrontmatterLines(lines)
+		updated = make(map[string]bool)
+	)
+	for i, line := range lines[:n] {
+		key := orgKeywordPattern.FindStringSubmatch(line)[1]
+		if value, ok := updates[strings.ToLower(key)]; ok {
+			lines[i] = fmt.Sprintf("#+%s: %v", key, value)
+			updated[strings.ToLower(key)] = true
+		}
+	}
+
+	var added []string
+	for k, v := range updates {
+		if !updated[k] {
+			added = append(added, fmt.Sprintf("#+%s: %v", k, v))
+		}
+	}
+	slices.Sort(added)
+
+	return strings.Join(slices.Concat(lines[:n], added, lines[n:]), "\n")
+}
+
+// tomlKeyPattern은 TOML front matter에서 key = value 형식의 줄과 그 key를 찾습니다.
+var tomlKeyPattern = regexp.MustCompile(`^([\w-]+|"[^"]*")[ \t]*=`)
+
+// tomlTablePattern은 TOML front matter의 [table], [[array]] 헤더 줄을 찾습니다.
+var tomlTablePattern = regexp.MustCompile(`^\[\[?[^\[\]]+\]\]?[ \t]*(#.*)?$`)
+
+// tomlValue는 value를 TOML 값 표현으로 변환합니다.
+// JSON 문자열의 escape는 TOML basic string에서도 유효하므로 문자열은 JSON으로 인코딩합니다.
+func tomlValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return jsonValue(v)
+	case bool, int, int64, float64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", errors.Errorf("unsupported TOML front matter value. value: %+v", value)
+	}
+}
+
+// updateTOMLFrontmatter는 TOML 형식의 front matter에서 updates의 key를 순서를 유지하며 업데이트(또는 추가)합니다.
+// 새로운 key는 테이블에 속하지 않도록 첫 번째 [table] 헤더 앞에 추가합니다.
+func updateTOMLFrontmatter(frontMatter string, updates map[string]interface{}) (string, error) {
+	var (
+		lines   []string
+		updated = make(map[string]bool)
+	)
+	if frontMatter != "" {
+		lines = strings.Split(frontMatter, "\n")
+	}
+
+	end := len(lines)
+	for i, line := range lines {
+		if tomlTablePattern.MatchString(strings.TrimSpace(line)) {
+			end = i
+			break
+		}
+
+		groups := tomlKeyPattern.FindStringSubmatch(line)
+		if groups == nil {
+			continue
+		}
+
+		key := strings.Trim(groups[1], `"`)
+		if v, ok := updates[key]; ok {
+			value, err := tomlValue(v)
+			if err != nil {
+				return "", err
+			}
+			lines[i] = fmt.Sprintf("%s = %s", groups[1], value)
+			updated[key] = true
+		}
+	}
+
+	var added []string
+	for k, v := range updates {
+		if updated[k] {
+			continue
+		}
+
+		value, err := tomlValue(v)
+		if err != nil {
+			return "", err
+		}
+		added = append(added, fmt.Sprintf("%s = %s", k, value))
+	}
+	slices.Sort(added)
+
+	// 테이블 앞의 빈 줄은 새로운 key 뒤에 오도록 유지
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+
+	return strings.Join(slices.Concat(lines[:end], added, lines[end:]), "\n"), nil
+}
+
+// jsonValue는 HTML 문자를 escape하지 않고 value를 JSON으로 인코딩합니다.
+func jsonValue(value interface{}) (string, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return "", errors.Wrapf(err, "JSON marshalling failed. value: %+v", value)
+	}
+
+	return strings.TrimSuffix(buf.String(), "\n"), nil
+}
+
+// jsonField는 key와 JSON으로 인코딩된 value로 JSON object의 field를 만듭니다.
+func jsonField(key, value string) (string, error) {
+	encodedKey, err := jsonValue(key)
+	if err != nil {
+		return "", err
+	}
+
+	return encodedKey + ":" + value, nil
+}
+
+// updateJSONFrontmatter는 content 맨 앞의 JSON front matter에서 updates의 key를 순서를 유지하며 업데이트(또는 추가)합니다.
+func updateJSONFrontmatter(content string, updates map[string]interface{}) (string, error) {
+	decoder := json.NewDecoder(strings.NewReader(content))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return "", errors.Errorf("invalid JSON front matter format. content: %s", content)
+	}
+
+	var (
+		fields  []string
+		updated = make(map[string]bool)
+	)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to read JSON front matter key")
+		}
+		key := token.(string)
+
+		var raw json.RawMessage
+		if err = decoder.Decode(&raw); err != nil {
+			return "", errors.Wrapf(err, "failed to read JSON front matter value. key: %s", key)
+		}
+
+		value := string(raw)
+		if v, ok := updates[key]; ok {
+			if value, err = jsonValue(v); err != nil {
+				return "", err
+			}
+			updated[key] = true
+		}
+		field, err := jsonField(key, value)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, field)
+	}
+	if _, err := decoder.Token(); err != nil {
+		return "", errors.Wrap(err, "failed to read end of JSON front matter")
+	}
+
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		if !updated[k] {
+			keys = append(keys, k)
+		}
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		value, err := jsonValue(updates[k])
+		if err != nil {
+			return "", err
+		}
+		field, err := jsonField(k, value)
+		if err != nil {
+			return "", err
+		}
+		fields = append(fields, field)
+	}
+
+	var object bytes.Buffer
+	if err := json.Indent(&object, []byte("{"+strings.Join(fields, ",")+"}"), "", "  "); err != nil {
+		return "", errors.Wrap(err, "failed to indent JSON front matter")
+	}
+
+	return object.String() + content[decoder.InputOffset():], nil
+}
+
+// parseFrontMatter는 YAML, TOML, JSON, Org 형식의 front matter를 v에 파싱합니다.
+func parseFrontMatter(content []byte, v interface{}) error {
+	if !bytes.HasPrefix(content, []byte(orgKeywordPrefix)) {
+		_, err := frontmatter.Parse(bytes.NewReader(content), v)
+		return err
+	}
+
+	data, err := yaml.Marshal(parseOrgFrontmatter(string(content)))
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal org front matter")
+	}
+
+	return yaml.Unmarshal(data, v)
+}
+
 // fixMismatchedQuotes는 front matter의 각 key: value 줄에서
 // 값이 따옴표(" 또는 ')로 시작했으나 동일한 따옴표로 종료되지 않는 경우
 // 내부 문자열을 추출해 올바른 double-quoted 문자열로 재생성합니다.
@@ -132,8 +376,14 @@ func updateFrontmatterPreserveOrder(frontMatter string, updates map[string]inter
 			updated[keyNode.Value] = true
 		}
 	}
-	// 존재하지 않는 key는 맨 뒤에 추가
-	for k, v := range updates {
+	// 존재하지 않는 key는 맨 뒤에 key 순서대로 추가
+	keys := make([]string, 0, len(updates))
+	for k := range updates {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		v := updates[k]
 		if !updated[k] {
 			keyNode := &yaml.Node{
 				Kind:  yaml.ScalarNode,
@@ -154,17 +404,17 @@ func updateFrontmatterPreserveOrder(frontMatter string, updates map[string]inter
 	return string(out), nil
 }
 
-// WriteMarkdownWithFrontmatter는 파일 내용을 업데이트합니다.
+// MarkdownWithFrontmatter는 file의 front matter에 keyValues를 업데이트(또는 추가)한 내용을 반환합니다.
 // keyValues는 key와 value 쌍으로 전달되며, key는 string, value는 any 타입이어야 합니다.
-func WriteMarkdownWithFrontmatter(path string, file []byte, perm os.FileMode, keyValues ...interface{}) error {
+func MarkdownWithFrontmatter(file []byte, keyValues ...interface{}) ([]byte, error) {
 	if len(keyValues)%2 != 0 {
-		return errors.New("keyValues must be provided key, value pairs")
+		return nil, errors.New("keyValues must be provided key, value pairs")
 	}
 	updates := make(map[string]interface{})
 	for i := 0; i < len(keyValues); i += 2 {
 		key, ok := keyValues[i].(string)
 		if !ok {
-			return errors.New("key in keyValues must be string")
+			return nil, errors.New("key in keyValues must be string")
 		}
 		updates[key] = keyValues[i+1]
 	}
@@ -172,30 +422,48 @@ func WriteMarkdownWithFrontmatter(path string, file []byte, perm os.FileMode, ke
 	contentStr := string(file)
 	var newContent string
 
-	if strings.HasPrefix(contentStr, "---") {
+	if strings.HasPrefix(contentStr, orgKeywordPrefix) {
+		// Org 형식의 front matter(#+key: value)인 경우
+		newContent = updateOrgFrontmatter(contentStr, updates)
+	} else if strings.HasPrefix(contentStr, "+++") {
+		// TOML 형식의 front matter인 경우
+		lines := strings.Split(contentStr, "\n")
+		end := slices.Index(lines[1:], "+++") + 1
+		if lines[0] != "+++" || end == 0 {
+			return nil, errors.Errorf("invalid front matter format. contentStr: %s", contentStr)
+		}
+
+		newToml, err := updateTOMLFrontmatter(strings.Join(lines[1:end], "\n"), updates)
+		if err != nil {
+			return nil, err
+		}
+		newContent = strings.Join(slices.Concat(lines[:1], []string{newToml}, lines[end:]), "\n")
+	} else if strings.HasPrefix(contentStr, "{") {
+		// JSON 형식의 front matter인 경우
+		var err error
+		if newContent, err = updateJSONFrontmatter(contentStr, updates); err != nil {
+			return nil, err
+		}
+	} else if strings.HasPrefix(contentStr, "---") {
 		// 기존 front matter가 있는 경우
 		parts := strings.SplitN(contentStr, "---", 3)
 		if len(parts) < 3 {
-			return errors.Errorf("invalid front matter format. contentStr: %s", contentStr)
+			return nil, errors.Errorf("invalid front matter format. contentStr: %s", contentStr)
 		}
 		// parts[1]에는 YAML front matter, parts[2]에는 본문 내용이 있음
 		newYaml, err := updateFrontmatterPreserveOrder(parts[1], updates)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		newContent = fmt.Sprintf("---\n%s---%s", newYaml, parts[2])
 	} else {
 		// front matter가 없는 경우, 새로 생성
 		newYaml, err := yaml.Marshal(updates)
 		if err != nil {
-			return errors.Wrap(err, "YAML marshalling 실패")
+			return nil, errors.Wrap(err, "YAML marshalling 실패")
 		}
 		newContent = fmt.Sprintf("---\n%s---\n%s", newYaml, contentStr)
 	}
 
-	if err := os.WriteFile(path, []byte(newContent), perm); err != nil {
-		return errors.Wrap(err, "파일 쓰기 실패")
-	}
-
-	return nil
+	return []byte(newContent), nil
 }
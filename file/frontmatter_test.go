@@ -7,7 +7,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
+func Test_MarkdownWithFrontmatter(t *testing.T) {
 	updateTestFile, err := os.ReadFile("test_md/update_test.md")
 	if err != nil {
 		t.Fatal(err)
@@ -31,10 +31,38 @@ func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	orgTestFile, err := os.ReadFile("test_md/org_test.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	orgWantFile, err := os.ReadFile("test_md/org_want.org")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tomlTestFile, err := os.ReadFile("test_md/toml_test.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tomlWantFile, err := os.ReadFile("test_md/toml_want.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsonTestFile, err := os.ReadFile("test_md/json_test.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	jsonWantFile, err := os.ReadFile("test_md/json_want.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+
 	type args struct {
-		path      string
 		file      []byte
-		perm      os.FileMode
 		keyValues []any
 	}
 	tests := []struct {
@@ -46,9 +74,7 @@ func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
 		{
 			name: "파라미터가 홀수인 경우",
 			args: args{
-				"test_md/odd_key_values.md",
 				[]byte(""),
-				os.ModePerm,
 				[]any{"translated"},
 			},
 			want:    "",
@@ -57,9 +83,7 @@ func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
 		{
 			name: "key가 string이 아닌 경우",
 			args: args{
-				"test_md/not_string_key.md",
 				[]byte(""),
-				os.ModePerm,
 				[]any{1, true},
 			},
 			want:    "",
@@ -68,9 +92,7 @@ func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
 		{
 			name: "업데이트 케이스",
 			args: args{
-				path:      "test_md/update_result.md",
 				file:      []byte(updateTestMd),
-				perm:      os.ModePerm,
 				keyValues: []any{"translated", true},
 			},
 			want:    string(updateWantFile),
@@ -79,29 +101,56 @@ func Test_WriteMarkdownWithFrontmatter(t *testing.T) {
 		{
 			name: "생성 케이스",
 			args: args{
-				path:      "test_md/create_result.md",
 				file:      []byte(createTestMd),
-				perm:      os.ModePerm,
 				keyValues: []any{"translated", true},
 			},
 			want:    string(createWantFile),
 			wantErr: false,
 		},
+		{
+			name: "Org 형식 케이스",
+			args: args{
+				file:      orgTestFile,
+				keyValues: []any{"translated", true},
+			},
+			want:    string(orgWantFile),
+			wantErr: false,
+		},
+		{
+			name: "TOML 형식 케이스",
+			args: args{
+				file:      tomlTestFile,
+				keyValues: []any{"translated", true, "translationKey", "post/test"},
+			},
+			want:    string(tomlWantFile),
+			wantErr: false,
+		},
+		{
+			name: "JSON 형식 케이스",
+			args: args{
+				file:      jsonTestFile,
+				keyValues: []any{"translated", true, "translationKey", "post/test"},
+			},
+			want:    string(jsonWantFile),
+			wantErr: false,
+		},
+		{
+			name: "TOML front matter가 닫히지 않은 경우",
+			args: args{
+				file:      []byte("+++\ntitle = \"test\"\n# test\n"),
+				keyValues: []any{"translated", true},
+			},
+			want:    "",
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			var gotFile []byte
-			err = WriteMarkdownWithFrontmatter(tt.args.path, tt.args.file, tt.args.perm, tt.args.keyValues...)
-			assert.Equalf(t, tt.wantErr, err != nil, "writeMarkdownWithFrontmatter() error = %v, wantErr %v", err, tt.wantErr)
+			got, err := MarkdownWithFrontmatter(tt.args.file, tt.args.keyValues...)
+			assert.Equalf(t, tt.wantErr, err != nil, "MarkdownWithFrontmatter() error = %v, wantErr %v", err, tt.wantErr)
 
 			if !tt.wantErr {
-				gotFile, err = os.ReadFile(tt.args.path)
-				if err != nil {
-					t.Fatal(err)
-				}
-
-				got := string(gotFile)
-				assert.Equalf(t, tt.want, got, "writeMarkdownWithFrontmatter() = %v, want %v", got, tt.want)
+				assert.Equal(t, tt.want, string(got))
 			}
 		})
 	}
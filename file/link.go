@@ -0,0 +1,352 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/samber/lo"
+)
+
+var (
+	// refRule은 위치 인자 하나로 경로를 지정한 ref, relref shortcode입니다. ex) {{< ref "post/foo.md" >}}
+	refRule = regexp.MustCompile(`\{\{([<%])(\s*)(relref|ref)\s+"([^"]*)"(\s*)([%>])\}\}`)
+
+	// urlRules는 첫 번째 그룹 뒤에 오는 두 번째 그룹이 사이트 루트 기준 URL인 링크입니다.
+	urlRules = []*regexp.Regexp{
+		// [text](/ko/post/foo/)
+		regexp.MustCompile(`(\]\()(/[^)\s]*)`),
+		// [text]: /ko/post/foo/
+		regexp.MustCompile(`(?m)(^[ \t]*\[[^\]\n]+\]:[ \t]*)(/\S+)`),
+		// <a href="/ko/post/foo/">
+		regexp.MustCompile(`(\bhref=")(/[^"]*)`),
+	}
+)
+
+// LinkLocalizer는 번역 결과물의 내부 링크를 번역 대상 언어의 페이지로 바꿉니다.
+type LinkLocalizer struct {
+	sourceLanguage config.LanguageCode
+	// sources는 ContentDir 기준 원본 파일 경로
+	sources map[string]bool
+	// pages는 언어 접두사를 뺀 원본 파일 페이지의 URL 경로별 원본 파일 경로
+	pages map[string]string
+	// translated는 번역 결과물이 이미 있거나 이번 실행에서 번역될 원본 파일과 언어
+	translated map[string]bool
+	// urls는 원본 파일과 언어별 번역 결과물 페이지의 언어 접두사를 뺀 URL 경로이며,
+	// 번역하면서 slug가 정해져 아직 URL을 알 수 없는 번역 결과물은 포함하지 않음
+	urls map[string]string
+}
+
+func linkKey(source string, lang config.LanguageCode) string {
+	return source + "\x00" + lang.String()
+}
+
+// LinkLocalizer는 원본 파일과 TargetPathRule로 번역 결과물이 있는 페이지를 찾아 LinkLocalizer를 만듭니다.
+// planned는 이번 실행에서 번역될 대상이며, 아직 번역 결과물이 없더라도 번역 결과물이 있는 것으로 봅니다.
+// slugged가 true이면 slug가 없는 번역 결과물은 번역하면서 slug가 정해지므로, 그 페이지로의 URL은 바꾸지 않습니다.
+func (p parser) LinkLocalizer(ctx context.Context, planned ContentFiles, slugged bool) (*LinkLocalizer, error) {
+	scan, err := p.scanOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	l := &LinkLocalizer{
+		sourceLanguage: p.cfg.SourceLanguage,
+		sources:        make(map[string]bool, len(scan.sources)),
+		pages:          make(map[string]string, len(scan.sources)),
+		translated:     make(map[string]bool),
+		urls:           make(map[string]string),
+	}
+
+	plannedKeys := make(map[string]bool, len(planned))
+	for _, contentFile := range planned {
+		plannedKeys[linkKey(contentFile.SourcePath, contentFile.Language)] = true
+	}
+
+	languages := append([]string{p.cfg.SourceLanguage.String()}, p.cfg.TargetLanguages.Strings()...)
+
+	for _, source := range scan.sources {
+		l.sources[source.path] = true
+		l.pages[pagePath(source.path, source.frontMatter, languages)] = source.path
+
+		for _, lang := range p.cfg.TargetLanguages {
+			contentFile, err := p.sourceContentFile(source, lang)
+			if err != nil {
+				return nil, err
+			}
+
+			targetFilePath, err := scan.rule.Render(contentFile.PathVars())
+			if err != nil {
+				slog.DebugContext(ctx, "failed to render target path", "path", source.path, "error", err)
+				continue
+			}
+
+			key := linkKey(source.path, lang)
+
+			output, ok := scan.matched[targetFilePath]
+			if ok || plannedKeys[key] {
+				l.translated[key] = true
+			}
+
+			// 이미 있는 번역 결과물의 slug는 다시 번역하더라도 그대로 사용되지만, slug가 없다면 번역하면서 새로 정해짐
+			slug, _ := output.frontMatter["slug"].(string)
+			switch {
+			case ok && (!slugged || !plannedKeys[key] || slug != ""):
+				l.urls[key] = pagePath(targetFilePath, output.frontMatter, languages)
+			case plannedKeys[key] && !slugged:
+				l.urls[key] = pagePath(targetFilePath, nil, languages)
+			}
+		}
+	}
+
+	return l, nil
+}
+
+// pagePath는 Hugo가 content 디렉터리 기준 경로가 filePath인 페이지를 제공하는 URL 경로에서 언어 접두사를 뺀 경로입니다.
+// 언어별 content 디렉터리(ex. ko/post/foo.md)와 파일 이름의 언어(ex. post/foo.ko.md)는 제외하며,
+// front matter에 slug가 있다면 마지막 경로 대신 사용합니다. ex) post/foo.en.md -> /post/foo/
+func pagePath(filePath string, frontMatter map[string]any, languages []string) string {
+	dir, base := path.Split(filePath)
+	dir = strings.Trim(dir, "/")
+	if first, rest, _ := strings.Cut(dir, "/"); slices.Contains(languages, first) {
+		dir = rest
+	}
+
+	name := strings.TrimSuffix(base, path.Ext(base))
+	if i := strings.LastIndex(name, "."); i >= 0 && slices.Contains(languages, name[i+1:]) {
+		name = name[:i]
+	}
+
+	slug, _ := frontMatter["slug"].(string)
+	switch {
+	case name == "_index":
+		// section은 slug를 사용하지 않음
+		name = ""
+	case name == "index" && slug != "":
+		// page bundle은 디렉터리 이름 대신 slug를 사용
+		dir, name = path.Dir(dir), slug
+	case name == "index":
+		name = ""
+	case slug != "":
+		name = slug
+	}
+
+	// Hugo는 기본적으로 URL을 소문자로 만듦
+	return strings.ToLower(strings.TrimSuffix(path.Join("/", dir, name), "/") + "/")
+}
+
+// Localize는 contentFile.Translated의 내부 링크를 contentFile.Language의 페이지로 바꾼 결과를 반환합니다.
+// code block과 inline code 안의 링크는 바꾸지 않습니다.
+//   - ref, relref shortcode는 경로를 그대로 두고 lang 인자를 지정합니다.
+//     번역 결과물이 없는 페이지는 번역 결과물의 언어에서 찾을 수 없으므로 원본 언어를 지정합니다.
+//   - 원본 파일 페이지의 URL(ex. /ko/post/foo/, /post/foo/)은 번역 결과물이 있는 페이지인 경우에만
+//     번역 결과물의 경로와 slug로 만든 /{대상 언어}/ URL로 바꿉니다.
+func (l *LinkLocalizer) Localize(contentFile ContentFile) Markdown {
+	return Markdown(outsideCode(contentFile.Translated.String(), func(text string) string {
+		return l.localize(text, contentFile)
+	}))
+}
+
+func (l *LinkLocalizer) localize(text string, contentFile ContentFile) string {
+	translated := refRule.ReplaceAllStringFunc(text, func(match string) string {
+		groups := refRule.FindStringSubmatch(match)
+		open, leading, name, ref, trailing, closing := groups[1], groups[2], groups[3], groups[4], groups[5], groups[6]
+
+		source, ok := l.resolveRef(contentFile.SourcePath, ref)
+		if !ok {
+			return match
+		}
+
+		lang := l.sourceLanguage
+		if l.translated[linkKey(source, contentFile.Language)] {
+			lang = contentFile.Language
+		}
+
+		return fmt.Sprintf(`{{%s%s%s path="%s" lang="%s"%s%s}}`, open, leading, name, ref, lang, trailing, closing)
+	})
+
+	for _, rule := range urlRules {
+		translated = rule.ReplaceAllStringFunc(translated, func(match string) string {
+			groups := rule.FindStringSubmatch(match)
+
+			return groups[1] + l.localizeURL(groups[2], contentFile.Language)
+		})
+	}
+
+	return translated
+}
+
+// resolveRef는 Hugo와 같이 페이지 기준 상대 경로, content 디렉터리 기준 경로 순으로 ref의 원본 파일을 찾습니다.
+func (l *LinkLocalizer) resolveRef(sourcePath, ref string) (string, bool) {
+	ref, _, _ = strings.Cut(ref, "#")
+	if ref == "" {
+		return "", false
+	}
+
+	if !strings.HasPrefix(ref, "/") {
+		if source, ok := l.resolve(path.Join(path.Dir(sourcePath), ref)); ok {
+			return source, true
+		}
+	}
+
+	return l.resolve(ref)
+}
+
+// localizeURL은 u가 번역 결과물이 있는 원본 파일 페이지의 URL이라면 번역 결과물 페이지의 /{lang}/ URL로 바꿉니다.
+// 원본 언어 접두사가 있는 URL(ex. /ko/post/foo/)과 없는 URL(ex. /post/foo/) 모두 원본 파일 페이지의 URL로 봅니다.
+func (l *LinkLocalizer) localizeURL(u string, lang config.LanguageCode) string {
+	page, suffix := u, ""
+	if i := strings.IndexAny(page, "#?"); i >= 0 {
+		page, suffix = page[:i], page[i:]
+	}
+
+	if rest, ok := strings.CutPrefix(page, "/"+l.sourceLanguage.String()); ok && (rest == "" || rest[0] == '/') {
+		page = rest
+	}
+	page = strings.ToLower(strings.TrimSuffix(path.Join("/", page), "/") + "/")
+
+	source, ok := l.pages[page]
+	if !ok {
+		return u
+	}
+
+	target, ok := l.urls[linkKey(source, lang)]
+	if !ok {
+		return u
+	}
+
+	return "/" + lang.String() + target + suffix
+}
+
+// outsideCode는 content에서 fenced code block과 inline code를 제외한 부분에만 replace를 적용합니다.
+func outsideCode(content string, replace func(string) string) string {
+	var (
+		sb    strings.Builder
+		text  strings.Builder
+		fence string
+	)
+
+	flush := func() {
+		sb.WriteString(outsideCodeSpans(text.String(), replace))
+		text.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case fence != "":
+			if strings.HasPrefix(trimmed, fence) {
+				fence = ""
+			}
+		case strings.HasPrefix(trimmed, "```"):
+			fence = "```"
+			flush()
+		case strings.HasPrefix(trimmed, "~~~"):
+			fence = "~~~"
+			flush()
+		default:
+			text.WriteString(line)
+			continue
+		}
+
+		sb.WriteString(line)
+	}
+	flush()
+
+	return sb.String()
+}
+
+// outsideCodeSpans는 text에서 backtick으로 감싼 inline code를 제외한 부분에만 replace를 적용합니다.
+// 닫는 backtick이 없는 backtick은 inline code가 아니므로 그대로 replace에 포함합니다.
+func outsideCodeSpans(text string, replace func(string) string) string {
+	var sb strings.Builder
+
+	for {
+		start := strings.IndexByte(text, '`')
+		if start < 0 {
+			break
+		}
+
+		end := start
+		for end < len(text) && text[end] == '`' {
+			end++
+		}
+
+		closing := closingBackticks(text[end:], end-start)
+		if closing < 0 {
+			break
+		}
+		closing += end + end - start
+
+		sb.WriteString(replace(text[:start]))
+		sb.WriteString(text[start:closing])
+		text = text[closing:]
+	}
+	sb.WriteString(replace(text))
+
+	return sb.String()
+}
+
+// closingBackticks는 text에서 길이가 정확히 n인 backtick 연속의 위치를 반환하며, 없다면 -1을 반환합니다.
+func closingBackticks(text string, n int) int {
+	for i := 0; i < len(text); {
+		if text[i] != '`' {
+			i++
+			continue
+		}
+
+		j := i
+		for j < len(text) && text[j] == '`' {
+			j++
+		}
+		if j-i == n {
+			return i
+		}
+		i = j
+	}
+
+	return -1
+}
+
+// resolve는 Hugo의 논리적 경로(ex. post/foo, post/foo.md)에 해당하는 원본 파일을 찾습니다.
+// 원본 언어 디렉터리(ex. content/ko/post/foo.md)에 있는 원본 파일도 찾습니다.
+func (l *LinkLocalizer) resolve(logicalPath string) (string, bool) {
+	logicalPath = strings.Trim(path.Clean("/"+logicalPath), "/")
+
+	for _, dir := range []string{"", l.sourceLanguage.String()} {
+		for _, candidate := range sourceCandidates(path.Join(dir, logicalPath)) {
+			if l.sources[candidate] {
+				return candidate, true
+			}
+		}
+	}
+
+	return "", false
+}
+
+// sourceCandidates는 논리적 경로가 가리킬 수 있는 원본 파일 경로를 반환합니다.
+// ex) post/foo -> post/foo.md, post/foo/index.md, post/foo/_index.md, ...
+func sourceCandidates(logicalPath string) []string {
+	if _, ok := FormatFromExtension(path.Ext(logicalPath)); ok {
+		return []string{logicalPath}
+	}
+
+	extensions := lo.Keys(extensionToFormat)
+	slices.Sort(extensions)
+
+	var candidates []string
+	for _, ext := range extensions {
+		if logicalPath != "" {
+			candidates = append(candidates, logicalPath+ext, path.Join(logicalPath, "index"+ext))
+		}
+		candidates = append(candidates, path.Join(logicalPath, "_index"+ext))
+	}
+
+	return candidates
+}
@@ -0,0 +1,158 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLinkLocalizer_Localize(t *testing.T) {
+	p := parser{
+		cfg: ParserConfig{
+			ContentDir:      "test_link_content",
+			SourceLanguage:  config.LanguageCodeKorean,
+			TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish, config.LanguageCodeJapanese},
+			TargetPathRule:  "{origin}/{fileName}.{language}.md",
+		},
+	}
+
+	// post/baz/index.md는 아직 번역 결과물이 없지만 이번 실행에서 영어로 번역됨
+	l, err := p.LinkLocalizer(t.Context(), ContentFiles{
+		{SourcePath: "post/baz/index.md", Language: config.LanguageCodeEnglish},
+	}, false)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		language   config.LanguageCode
+		translated Markdown
+		want       Markdown
+	}{
+		{
+			name:       "번역 결과물이 있는 페이지의 URL",
+			language:   config.LanguageCodeEnglish,
+			translated: "See [foo](/ko/post/foo/) and [baz](/ko/post/baz/#intro).",
+			want:       "See [foo](/en/post/foo/) and [baz](/en/post/baz/#intro).",
+		},
+		{
+			name:       "번역 결과물이 없는 페이지의 URL은 그대로",
+			language:   config.LanguageCodeJapanese,
+			translated: "See [foo](/ko/post/foo/) and [bar](/ko/post/bar/).",
+			want:       "See [foo](/ko/post/foo/) and [bar](/ko/post/bar/).",
+		},
+		{
+			name:       "참조 링크와 HTML 링크",
+			language:   config.LanguageCodeEnglish,
+			translated: "<a href=\"/ko/post/foo/\">foo</a>\n\n[foo]: /ko/post/foo/\n",
+			want:       "<a href=\"/en/post/foo/\">foo</a>\n\n[foo]: /en/post/foo/\n",
+		},
+		{
+			name:       "외부 링크와 원본 파일이 없는 URL은 그대로",
+			language:   config.LanguageCodeEnglish,
+			translated: "[a](https://example.com/ko/post/foo/) [b](/korean/) [c](/ko/tags/go/)",
+			want:       "[a](https://example.com/ko/post/foo/) [b](/korean/) [c](/ko/tags/go/)",
+		},
+		{
+			name:       "원본 언어 접두사가 없는 URL",
+			language:   config.LanguageCodeEnglish,
+			translated: "See [foo](/post/foo) and [baz](/post/baz/?page=2).",
+			want:       "See [foo](/en/post/foo/) and [baz](/en/post/baz/?page=2).",
+		},
+		{
+			name:       "code block과 inline code 안의 링크는 그대로",
+			language:   config.LanguageCodeEnglish,
+			translated: "`[foo](/ko/post/foo/)` [foo](/ko/post/foo/)\n\n```md\n[foo](/ko/post/foo/)\n```\n\n``a ` [foo](/ko/post/foo/)``\n",
+			want:       "`[foo](/ko/post/foo/)` [foo](/en/post/foo/)\n\n```md\n[foo](/ko/post/foo/)\n```\n\n``a ` [foo](/ko/post/foo/)``\n",
+		},
+		{
+			name:       "ref, relref shortcode",
+			language:   config.LanguageCodeEnglish,
+			translated: `{{< ref "foo.md" >}} {{< relref "/post/bar.md#top" >}} {{% ref "missing.md" %}} {{< ref path="foo.md" lang="ja" >}}`,
+			want:       `{{< ref path="foo.md" lang="en" >}} {{< relref path="/post/bar.md#top" lang="ko" >}} {{% ref "missing.md" %}} {{< ref path="foo.md" lang="ja" >}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := l.Localize(ContentFile{
+				SourcePath: "post/page.md",
+				Language:   tt.language,
+				Translated: tt.translated,
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestLinkLocalizer_Localize_Slug(t *testing.T) {
+	contentDir := t.TempDir()
+	for name, content := range map[string]string{
+		"ko/post/foo.md": "---\ntitle: foo\n---\n# foo",
+		"en/post/foo.md": "---\ntitle: foo\ntranslated: true\nslug: english-foo\n---\n# foo",
+		"ko/post/bar.md": "---\ntitle: bar\nslug: bar-ko\n---\n# bar",
+		"ko/post/baz.md": "---\ntitle: baz\n---\n# baz",
+	} {
+		path := filepath.Join(contentDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := parser{
+		cfg: ParserConfig{
+			ContentDir:      contentDir,
+			SourceLanguage:  config.LanguageCodeKorean,
+			TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish},
+			TargetPathRule:  "{language}/{origin}/{fileName}.md",
+		},
+	}
+	// bar, baz는 아직 번역 결과물이 없지만 이번 실행에서 영어로 번역됨
+	planned := ContentFiles{
+		{SourcePath: "ko/post/bar.md", Language: config.LanguageCodeEnglish},
+		{SourcePath: "ko/post/baz.md", Language: config.LanguageCodeEnglish},
+	}
+
+	tests := []struct {
+		name       string
+		slugged    bool
+		translated Markdown
+		want       Markdown
+	}{
+		{
+			name:       "번역 결과물의 slug로 URL을 만듦",
+			slugged:    true,
+			translated: "[foo](/ko/post/foo/#intro)",
+			want:       "[foo](/en/post/english-foo/#intro)",
+		},
+		{
+			name:       "번역하면서 slug가 정해지는 페이지의 URL은 그대로",
+			slugged:    true,
+			translated: "[bar](/ko/post/bar-ko/) [baz](/ko/post/baz/)",
+			want:       "[bar](/ko/post/bar-ko/) [baz](/ko/post/baz/)",
+		},
+		{
+			name:       "원본 파일의 slug로 원본 페이지를 찾음",
+			slugged:    false,
+			translated: "[bar](/ko/post/bar-ko/) [baz](/ko/post/baz/)",
+			want:       "[bar](/en/post/bar/) [baz](/en/post/baz/)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l, err := p.LinkLocalizer(t.Context(), planned, tt.slugged)
+			assert.NoError(t, err)
+
+			got := l.Localize(ContentFile{
+				SourcePath: "ko/post/page.md",
+				Language:   config.LanguageCodeEnglish,
+				Translated: tt.translated,
+			})
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,7 +1,6 @@
 package file
 
 import (
-	"bytes"
 	"context"
 	"io/fs"
 	"log/slog"
@@ -12,8 +11,9 @@ import (
 	"strings"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
-	"github.com/adrg/frontmatter"
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
 	"github.com/bmatcuk/doublestar/v4"
+	"github.com/pkg/errors"
 )
 
 type Markdown string
@@ -38,11 +38,26 @@ type ParserConfig struct {
 	TargetLanguages config.LanguageCodes
 	SourceLanguage  config.LanguageCode
 	TargetPathRule  string
+	Extensions      []string
+	// Recursive, SkipTranslated는 Simple에서만 사용하며, Parse는 항상 하위 디렉터리를 탐색하고 번역된 파일을 건너뜀
+	Recursive      bool
+	SkipTranslated bool
+	// Sources가 nil이 아니면 ContentDir 기준 경로가 Sources에 포함된 원본 파일만 번역
+	// 번역 결과물 판별에는 모든 파일을 사용하므로 ignore rule과 target path rule은 그대로 적용됨
+	Sources []string
+	// UpdateStale이 true이면 이미 번역된 언어라도 번역 결과물의 source_hash가 현재 원본과 다르면 다시 번역
+	UpdateStale bool
 }
 
 type Parser interface {
-	Parse(ctx context.Context) (MarkdownFiles, error)
-	Simple(ctx context.Context) (MarkdownFiles, error)
+	Parse(ctx context.Context) (ContentFiles, error)
+	Simple(ctx context.Context) (ContentFiles, error)
+	Orphans(ctx context.Context) ([]string, error)
+	UnusedSnapshots(ctx context.Context) ([]string, error)
+	Relocations(ctx context.Context, renames map[string]string) ([]Relocation, error)
+	ParseFile(ctx context.Context, filePath string) (ContentFiles, error)
+	LinkLocalizer(ctx context.Context, planned ContentFiles, slugged bool) (*LinkLocalizer, error)
+	SlugRegistry(ctx context.Context) (*SlugRegistry, error)
 }
 
 type parser struct {
@@ -55,66 +70,47 @@ func NewParser(cfg ParserConfig) Parser {
 	}
 }
 
-func (p parser) Simple(_ context.Context) (MarkdownFiles, error) {
-	// ContentDir에 있는 모든 .md파일을 읽어서 반환
-	var markdownFiles MarkdownFiles
+func (p parser) Simple(ctx context.Context) (ContentFiles, error) {
+	// ContentDir에 있는 모든 컨텐츠 파일을 읽어서 반환
+	// Recursive가 설정되어 있으면 하위 디렉터리까지 탐색
+	if p.cfg.TargetPathRule == "" {
+		p.cfg.TargetPathRule = config.SimpleTargetPathRule
+	}
 
-	paths, err := os.ReadDir(p.cfg.ContentDir)
+	filePaths, err := p.listContentFilePaths(p.cfg.Recursive)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, path := range paths {
-		var (
-			file     []byte
-			fileName string
-		)
-
-		if path.IsDir() {
-			continue
-		}
-
-		if !strings.HasSuffix(path.Name(), ".md") {
-			continue
-		}
-
-		file, err = os.ReadFile(filepath.Join(p.cfg.ContentDir, path.Name()))
-		if err != nil {
-			return nil, err
-		}
-
-		fileName, err = FileNameWithoutExtension(path.Name())
-		if err != nil {
-			return nil, err
-		}
-
-		for _, language := range p.cfg.TargetLanguages {
-			markdownFiles = append(markdownFiles, MarkdownFile{
-				OriginDir: filepath.Dir(path.Name()),
-				FileName:  fileName,
-				Content:   Markdown(file),
-				Language:  language,
-			})
-		}
-
-	}
+	// simple 커맨드가 이전에 생성한 결과물(ex. post.en.md)은 어떤 언어로 번역했든 다시 번역하지 않음
+	return p.parseContentFiles(ctx, filePaths, parseOptions{
+		skipTranslated:  p.cfg.SkipTranslated,
+		keepOriginDir:   true,
+		outputLanguages: config.LanguageCodeToLanguage.Keys(),
+	})
+}
 
-	return markdownFiles, nil
+func (p parser) listContentFilePaths(recursive bool) ([]string, error) {
+	return p.walkContentFilePaths(recursive, p.cfg.IgnoreRules)
 }
 
-func (p parser) listMarkdownFilePaths() ([]string, error) {
+func (p parser) walkContentFilePaths(recursive bool, ignoreRules []string) ([]string, error) {
 	var results []string
 
 	if err := filepath.WalkDir(p.cfg.ContentDir, func(filePath string, d fs.DirEntry, err error) error {
 		if err != nil {
 			return err
 		}
-		// 디렉터리는 스킵
+		// 디렉터리는 스킵, recursive가 아니면 하위 디렉터리도 탐색하지 않음
 		if d.IsDir() {
+			if !recursive && filePath != p.cfg.ContentDir {
+				return filepath.SkipDir
+			}
+
 			return nil
 		}
-		// 확장자가 .md가 아니면 무시
-		if !strings.HasSuffix(d.Name(), ".md") {
+		// 번역 대상 확장자가 아니면 무시
+		if !hasExtension(d.Name(), p.cfg.Extensions) {
 			return nil
 		}
 
@@ -123,18 +119,9 @@ func (p parser) listMarkdownFilePaths() ([]string, error) {
 			return err
 		}
 
-		// glob 패턴 매칭은 Unix 스타일 경로 구분자를 사용하는 것이 좋으므로 변환
-		relPathUnix := filepath.ToSlash(relPath)
-		// ignoreRules와 매칭되는지 확인
-		for _, rule := range p.cfg.IgnoreRules {
-			match, err := doublestar.PathMatch(rule, relPathUnix)
-			if err != nil {
-				return err
-			}
-			if match {
-				// ignoreRules와 일치하면 결과에 추가하지 않음
-				return nil
-			}
+		// ignoreRules와 일치하면 결과에 추가하지 않음
+		if ignored, err := matchIgnoreRules(relPath, ignoreRules); err != nil || ignored {
+			return err
 		}
 
 		// 해당 파일은 포함
@@ -148,85 +135,282 @@ func (p parser) listMarkdownFilePaths() ([]string, error) {
 	return results, nil
 }
 
-func (p parser) Parse(ctx context.Context) (MarkdownFiles, error) {
+// matchIgnoreRules는 ContentDir 기준 경로 relPath가 ignoreRules 중 하나와 매칭되는지 확인합니다.
+func matchIgnoreRules(relPath string, ignoreRules []string) (bool, error) {
+	// glob 패턴 매칭은 Unix 스타일 경로 구분자를 사용하는 것이 좋으므로 변환
+	relPathUnix := filepath.ToSlash(relPath)
+	for _, rule := range ignoreRules {
+		match, err := doublestar.PathMatch(rule, relPathUnix)
+		if err != nil {
+			return false, err
+		}
+		if match {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (p parser) Parse(ctx context.Context) (ContentFiles, error) {
+	filePaths, err := p.listContentFilePaths(true)
+	if err != nil {
+		return nil, err
+	}
+	slog.DebugContext(ctx, "file path pattern match finished", "count", len(filePaths))
+
+	return p.parseContentFiles(ctx, filePaths, parseOptions{
+		skipTranslated:  true,
+		outputLanguages: p.cfg.TargetLanguages,
+	})
+}
+
+// originDir는 filePath의 디렉터리에서 SourceLanguage 디렉터리를 제거한 경로를 반환합니다.
+// ex) /en/docs -> /docs
+func (p parser) originDir(filePath string) string {
+	fragments := strings.Split(filepath.Dir(filePath), "/")
+	if i := slices.Index(fragments, p.cfg.SourceLanguage.String()); i >= 0 {
+		fragments = append(fragments[:i], fragments[i+1:]...)
+	}
+
+	return filepath.Join(fragments...)
+}
+
+type sourceFile struct {
+	path        string
+	content     []byte
+	frontMatter map[string]any
+}
+
+type parseOptions struct {
+	// skipTranslated가 true이면 이미 번역된 파일과 이미 번역된 언어를 건너뜀
+	skipTranslated bool
+	// keepOriginDir가 true이면 OriginDir에서 SourceLanguage 디렉터리를 제거하지 않음
+	keepOriginDir bool
+	// outputLanguages 중 하나의 TargetPathRule과 매칭되는 파일은 번역 결과물로 간주하여 번역하지 않음
+	outputLanguages config.LanguageCodes
+}
+
+func (p parser) parseContentFiles(ctx context.Context, filePaths []string, opts parseOptions) (ContentFiles, error) {
 	var (
-		markdownFiles MarkdownFiles
+		contentFiles  ContentFiles
+		sources       []sourceFile
 		translatedMap = make(map[string]bool)
 	)
 
-	filePaths, err := p.listMarkdownFilePaths()
+	rule, err := pathrule.Parse(p.cfg.TargetPathRule)
 	if err != nil {
 		return nil, err
 	}
-	slog.DebugContext(ctx, "file path pattern match finished", "count", len(filePaths))
+	outputMatcher := rule.Matcher(opts.outputLanguages.Strings())
 
-	// 파일 경로를 순회하면서 front matter를 파싱
-	// 파싱한 front matter에 translated가 true로 설정되어 있으면 이미 번역된 파일로 간주,
-	// translatedMap에 파일 경로를 키로 추가하고 이미 번역된 파일을 다시 번역하지 않기 위해 skip
+	var sourceSet map[string]bool
+	if p.cfg.Sources != nil {
+		sourceSet = make(map[string]bool, len(p.cfg.Sources))
+		for _, source := range p.cfg.Sources {
+			sourceSet[path.Clean(filepath.ToSlash(source))] = true
+		}
+	}
+
+	// 파일 경로를 순회하면서 번역 결과물과 원본 파일을 구분
+	// TargetPathRule과 매칭되는 파일은 translated 여부와 관계없이 번역 결과물로 간주하고,
+	// front matter에 translated가 true로 설정된 파일도 이미 번역된 파일로 간주
+	// 두 경우 모두 translatedMap에 파일 경로를 키로 추가하고 이미 번역된 파일을 다시 번역하지 않기 위해 skip
 	for _, filePath := range filePaths {
-		var (
-			file     []byte
-			fileName string
-		)
+		var file []byte
+
+		if outputMatcher.MatchString(filepath.ToSlash(filePath)) {
+			translatedMap[filepath.ToSlash(filePath)] = true
+			slog.DebugContext(ctx, "skip translated output", "path", filePath)
+			continue
+		}
 
 		file, err = os.ReadFile(path.Join(p.cfg.ContentDir, filePath))
 		if err != nil {
 			return nil, err
 		}
 
-		var frontMatter struct {
-			Translated bool `yaml:"translated"`
-		}
-
-		if _, err = frontmatter.Parse(bytes.NewReader(file), &frontMatter); err != nil {
+		var frontMatter map[string]any
+		if err = parseFrontMatter(file, &frontMatter); err != nil {
 			return nil, err
 		}
 
-		if frontMatter.Translated {
-			translatedMap[filePath] = true
+		if translated, _ := frontMatter["translated"].(bool); translated && opts.skipTranslated {
+			translatedMap[filepath.ToSlash(filePath)] = true
 			slog.DebugContext(ctx, "skip already translated file", "path", filePath)
 			continue
 		}
 
+		if sourceSet != nil && !sourceSet[filepath.ToSlash(filePath)] {
+			slog.DebugContext(ctx, "skip source not in sources", "path", filePath)
+			continue
+		}
+
+		if len(frontMatter) == 0 {
+			frontMatter = nil
+		}
+
+		sources = append(sources, sourceFile{path: filePath, content: file, frontMatter: frontMatter})
+	}
+
+	for _, source := range sources {
+		var (
+			filePath = source.path
+			fileName string
+		)
+
 		fileName, err = FileNameWithoutExtension(filePath)
 		if err != nil {
 			return nil, err
 		}
 
-		for _, lang := range p.cfg.TargetLanguages {
-			targetFilePath := strings.TrimPrefix(
-				TargetFilePath(p.cfg.TargetPathRule, filepath.Dir(filePath), lang.String(), fileName),
-				"./",
-			)
+		originDir := filepath.Dir(filePath)
+		if !opts.keepOriginDir {
+			originDir = p.originDir(filePath)
+		}
 
-			slog.Debug("output path for translated markdown", "path", targetFilePath)
+		for _, lang := range p.cfg.TargetLanguages {
+			contentFile := ContentFile{
+				SourcePath:  filepath.ToSlash(filePath),
+				OriginDir:   originDir,
+				Content:     Markdown(source.content),
+				FileName:    fileName,
+				Ext:         filepath.Ext(filePath),
+				Language:    lang,
+				FrontMatter: source.frontMatter,
+			}
 
-			if _, ok := translatedMap[targetFilePath]; ok {
-				slog.DebugContext(ctx, "skip already translated language", "path", targetFilePath, "language", lang)
-				continue
+			targetFilePath, err := rule.Render(contentFile.PathVars())
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to render target path of %s", filePath)
 			}
 
-			originDir := filepath.Dir(filePath)
+			slog.DebugContext(ctx, "output path for translated content", "path", targetFilePath)
 
-			// OriginDir이 SourceLanguage를 포함하고 있는 경우 제거
-			// ex) /en/docs -> /docs
-			fragments := strings.Split(originDir, "/")
-			if len(fragments) > 0 {
-				if i := slices.Index(fragments, p.cfg.SourceLanguage.String()); i >= 0 {
-					fragments = append(fragments[:i], fragments[i+1:]...)
+			if _, ok := translatedMap[targetFilePath]; ok {
+				stale, err := p.loadPrevious(&contentFile, targetFilePath)
+				if err != nil {
+					return nil, err
 				}
 
-				originDir = filepath.Join(fragments...)
+				if opts.skipTranslated && !(stale && p.cfg.UpdateStale) {
+					slog.DebugContext(ctx, "skip already translated language", "path", targetFilePath, "language", lang)
+					continue
+				}
 			}
 
-			markdownFiles = append(markdownFiles, MarkdownFile{
-				OriginDir: originDir,
-				Content:   Markdown(file),
-				FileName:  fileName,
-				Language:  lang,
-			})
+			contentFiles = append(contentFiles, contentFile)
+		}
+	}
+
+	return contentFiles, nil
+}
+
+// ParseFile은 ContentDir 기준 경로 filePath의 원본 파일 하나를 모든 TargetLanguages로 번역하기 위한 ContentFiles를 반환합니다.
+// 원본이 수정되었다고 보고 이미 번역된 언어도 다시 번역하며,
+// 번역 대상 확장자가 아니거나 ignore rule과 일치하거나 번역 결과물인 파일이면 빈 결과를 반환합니다.
+func (p parser) ParseFile(ctx context.Context, filePath string) (ContentFiles, error) {
+	filePath = filepath.ToSlash(filepath.Clean(filePath))
+
+	if !hasExtension(filePath, p.cfg.Extensions) {
+		return nil, nil
+	}
+
+	if ignored, err := matchIgnoreRules(filePath, p.cfg.IgnoreRules); err != nil || ignored {
+		slog.DebugContext(ctx, "skip ignored file", "path", filePath)
+		return nil, err
+	}
+
+	rule, err := pathrule.Parse(p.cfg.TargetPathRule)
+	if err != nil {
+		return nil, err
+	}
+
+	if rule.Matcher(p.cfg.TargetLanguages.Strings()).MatchString(filePath) {
+		slog.DebugContext(ctx, "skip translated output", "path", filePath)
+		return nil, nil
+	}
+
+	file, err := os.ReadFile(path.Join(p.cfg.ContentDir, filePath))
+	if err != nil {
+		return nil, err
+	}
+
+	var frontMatter map[string]any
+	if err = parseFrontMatter(file, &frontMatter); err != nil {
+		return nil, err
+	}
+
+	if translated, _ := frontMatter["translated"].(bool); translated {
+		slog.DebugContext(ctx, "skip already translated file", "path", filePath)
+		return nil, nil
+	}
+
+	if len(frontMatter) == 0 {
+		frontMatter = nil
+	}
+
+	var (
+		source       = sourceFile{path: filePath, content: file, frontMatter: frontMatter}
+		contentFiles ContentFiles
+	)
+	for _, lang := range p.cfg.TargetLanguages {
+		contentFile, err := p.sourceContentFile(source, lang)
+		if err != nil {
+			return nil, err
+		}
+
+		targetFilePath, err := rule.Render(contentFile.PathVars())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render target path of %s", filePath)
+		}
+
+		if _, err = p.loadPrevious(&contentFile, targetFilePath); err != nil {
+			return nil, err
 		}
+
+		contentFiles = append(contentFiles, contentFile)
+	}
+
+	return contentFiles, nil
+}
+
+// loadPrevious는 targetFilePath에 이미 있는 번역 결과물과, 그 번역 결과물을 번역한 원본이 보관되어 있다면 그 원본을 contentFile에 담습니다.
+// 번역 결과물의 source_hash가 현재 원본과 다르면 원본이 수정된 것이므로 true를 반환하며,
+// source_hash가 없는 번역 결과물은 원본이 수정되었는지 알 수 없으므로 false를 반환합니다.
+func (p parser) loadPrevious(contentFile *ContentFile, targetFilePath string) (bool, error) {
+	previous, err := p.previousTranslation(targetFilePath)
+	if err != nil || previous == "" {
+		return false, err
+	}
+	contentFile.Previous = previous
+
+	var frontMatter map[string]any
+	if err = parseFrontMatter([]byte(previous), &frontMatter); err != nil {
+		return false, errors.Wrapf(err, "failed to parse front matter of %s", targetFilePath)
+	}
+
+	hash, _ := frontMatter["source_hash"].(string)
+	if hash == "" || hash == SourceHash(contentFile.Content) {
+		return false, nil
+	}
+
+	if contentFile.PreviousSource, err = NewSourceSnapshots(p.cfg.ContentDir).Load(hash); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// previousTranslation은 ContentDir 기준 경로 targetFilePath에 이미 있는 번역 결과물의 내용을 반환하며, 없다면 빈 문자열을 반환합니다.
+func (p parser) previousTranslation(targetFilePath string) (Markdown, error) {
+	content, err := os.ReadFile(path.Join(p.cfg.ContentDir, targetFilePath))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read previous translation %s", targetFilePath)
 	}
 
-	return markdownFiles, nil
+	return Markdown(content), nil
 }
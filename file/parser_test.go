@@ -27,7 +27,7 @@ func Test_parser_Parse(t *testing.T) {
 	tests := []struct {
 		name    string
 		fields  fields
-		want    MarkdownFiles
+		want    ContentFiles
 		wantErr bool
 	}{
 		{
@@ -41,22 +41,79 @@ func Test_parser_Parse(t *testing.T) {
 					TargetLanguages: config.LanguageCodes{
 						config.LanguageCodeEnglish,
 					},
-					TargetPathRule: "{{origin}}/{{filename}}.{{language}}.md",
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
 					SourceLanguage: config.LanguageCodeKorean,
 				},
 			},
-			want: MarkdownFiles{
+			want: ContentFiles{
 				{
-					OriginDir: "hello",
-					FileName:  "bar",
-					Language:  config.LanguageCodeEnglish,
-					Content:   Markdown(barMd),
+					SourcePath: "hello/bar.md",
+					OriginDir:  "hello",
+					FileName:   "bar",
+					Ext:        ".md",
+					Language:   config.LanguageCodeEnglish,
+					Content:    Markdown(barMd),
 				},
 				{
-					OriginDir: "hello",
-					FileName:  "foo",
-					Language:  config.LanguageCodeEnglish,
-					Content:   Markdown(fooMd),
+					SourcePath: "hello/foo.md",
+					OriginDir:  "hello",
+					FileName:   "foo",
+					Ext:        ".md",
+					Language:   config.LanguageCodeEnglish,
+					Content:    Markdown(fooMd),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "translated가 없어도 TargetPathRule과 매칭되는 파일은 번역 결과물로 간주",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir: "./test_output_content",
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+						config.LanguageCodeJapanese,
+					},
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					SourceLanguage: config.LanguageCodeKorean,
+				},
+			},
+			want: ContentFiles{
+				{
+					SourcePath: "post/hello.md",
+					OriginDir:  "post",
+					FileName:   "hello",
+					Ext:        ".md",
+					Language:   config.LanguageCodeEnglish,
+					Content:    "# 글",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sources에 포함된 원본 파일만 반환",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir: "./test_content",
+					IgnoreRules: []string{
+						"world/**",
+					},
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+					},
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					SourceLanguage: config.LanguageCodeKorean,
+					Sources:        []string{"./hello/foo.md", "world/foo.md"},
+				},
+			},
+			want: ContentFiles{
+				{
+					SourcePath: "hello/foo.md",
+					OriginDir:  "hello",
+					FileName:   "foo",
+					Ext:        ".md",
+					Language:   config.LanguageCodeEnglish,
+					Content:    Markdown(fooMd),
 				},
 			},
 			wantErr: false,
@@ -94,7 +151,7 @@ func Test_parser_Simple(t *testing.T) {
 		name    string
 		fields  fields
 		args    args
-		want    MarkdownFiles
+		want    ContentFiles
 		wantErr bool
 	}{
 		{
@@ -111,23 +168,70 @@ func Test_parser_Simple(t *testing.T) {
 			args: args{
 				ctx: t.Context(),
 			},
-			want: MarkdownFiles{
+			want: ContentFiles{
 				{
-					FileName:  "test",
-					Content:   "# Hello, World!",
-					OriginDir: ".",
-					Language:  config.LanguageCodeEnglish,
+					SourcePath: "test.md",
+					FileName:   "test",
+					Ext:        ".md",
+					Content:    "# Hello, World!",
+					OriginDir:  ".",
+					Language:   config.LanguageCodeEnglish,
 				},
 				{
-					FileName:  "test",
-					Content:   "# Hello, World!",
-					OriginDir: ".",
-					Language:  config.LanguageCodeKorean,
+					SourcePath: "test.md",
+					FileName:   "test",
+					Ext:        ".md",
+					Content:    "# Hello, World!",
+					OriginDir:  ".",
+					Language:   config.LanguageCodeKorean,
 				},
 			},
 
 			wantErr: false,
 		},
+		{
+			name: "recursive, ignore, skip-translated 설정 시",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir:      path.Join(currentDir, "test_simple_recursive_dir"),
+					IgnoreRules:     []string{"drafts/**"},
+					TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish, config.LanguageCodeJapanese},
+					TargetPathRule:  config.SimpleTargetPathRule,
+					Recursive:       true,
+					SkipTranslated:  true,
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+			},
+			want: ContentFiles{
+				{
+					SourcePath: "post.md",
+					FileName:   "post",
+					Ext:        ".md",
+					Content:    "# 글",
+					OriginDir:  ".",
+					Language:   config.LanguageCodeJapanese,
+				},
+				{
+					SourcePath: "sub/child.md",
+					FileName:   "child",
+					Ext:        ".md",
+					Content:    "# 하위",
+					OriginDir:  "sub",
+					Language:   config.LanguageCodeEnglish,
+				},
+				{
+					SourcePath: "sub/child.md",
+					FileName:   "child",
+					Ext:        ".md",
+					Content:    "# 하위",
+					OriginDir:  "sub",
+					Language:   config.LanguageCodeJapanese,
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -146,7 +250,7 @@ func TestNewParser(t *testing.T) {
 		ContentDir:      "test_content",
 		IgnoreRules:     []string{"world/**"},
 		TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish},
-		TargetPathRule:  "{{origin}}/{{filename}}.{{language}}.md",
+		TargetPathRule:  "{origin}/{fileName}.{language}.md",
 		SourceLanguage:  config.LanguageCodeKorean,
 	}
 	type args struct {
@@ -172,3 +276,235 @@ func TestNewParser(t *testing.T) {
 		assert.Equalf(t, tt.want, p, "NewParser() = %v, want %v", p, tt.want)
 	}
 }
+
+func Test_parser_Orphans(t *testing.T) {
+	type fields struct {
+		cfg ParserConfig
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "원본이 없는 번역 결과물만 반환",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir: "./test_prune_content",
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+						config.LanguageCodeJapanese,
+					},
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					SourceLanguage: config.LanguageCodeKorean,
+				},
+			},
+			want:    []string{"post/b.en.md", "post/b.ja.md"},
+			wantErr: false,
+		},
+		{
+			name: "잘못된 rule",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir:     "./test_prune_content",
+					TargetPathRule: "{origin}/{fileName}.md",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{
+				cfg: tt.fields.cfg,
+			}
+
+			got, err := p.Orphans(t.Context())
+			assert.Equalf(t, tt.wantErr, err != nil, "parser.Orphans() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equalf(t, tt.want, got, "parser.Orphans()")
+		})
+	}
+}
+
+func Test_parser_Relocations(t *testing.T) {
+	type fields struct {
+		cfg ParserConfig
+	}
+	type args struct {
+		renames map[string]string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    []Relocation
+		wantErr bool
+	}{
+		{
+			name: "이름이 바뀐 원본과 source_hash가 같은 원본으로 이동",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir: "./test_relocate_content",
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+						config.LanguageCodeJapanese,
+					},
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					SourceLanguage: config.LanguageCodeKorean,
+				},
+			},
+			args: args{
+				renames: map[string]string{
+					"post/foo.md": "post/bar.md",
+				},
+			},
+			want: []Relocation{
+				{
+					From:           "post/foo.en.md",
+					To:             "post/bar.en.md",
+					Source:         "post/bar.md",
+					Language:       config.LanguageCodeEnglish,
+					TranslationKey: "foo",
+				},
+				{
+					From:           "post/old.en.md",
+					To:             "post/moved.en.md",
+					Source:         "post/moved.md",
+					Language:       config.LanguageCodeEnglish,
+					TranslationKey: "post/moved",
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "renames가 없으면 source_hash로만 이동",
+			fields: fields{
+				cfg: ParserConfig{
+					ContentDir: "./test_relocate_content",
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+					},
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+					SourceLanguage: config.LanguageCodeKorean,
+				},
+			},
+			args: args{
+				renames: nil,
+			},
+			want: []Relocation{
+				{
+					From:           "post/old.en.md",
+					To:             "post/moved.en.md",
+					Source:         "post/moved.md",
+					Language:       config.LanguageCodeEnglish,
+					TranslationKey: "post/moved",
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{
+				cfg: tt.fields.cfg,
+			}
+
+			got, err := p.Relocations(t.Context(), tt.args.renames)
+			assert.Equalf(t, tt.wantErr, err != nil, "parser.Relocations() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equalf(t, tt.want, got, "parser.Relocations()")
+		})
+	}
+}
+
+func Test_parser_ParseFile(t *testing.T) {
+	cfg := ParserConfig{
+		ContentDir: "./test_content",
+		IgnoreRules: []string{
+			"world/**",
+		},
+		TargetLanguages: config.LanguageCodes{
+			config.LanguageCodeEnglish,
+			config.LanguageCodeJapanese,
+		},
+		TargetPathRule: "{origin}/{fileName}.{language}.md",
+		SourceLanguage: config.LanguageCodeKorean,
+	}
+
+	tests := []struct {
+		name     string
+		cfg      ParserConfig
+		filePath string
+		want     ContentFiles
+		wantErr  bool
+	}{
+		{
+			name:     "모든 대상 언어로 번역",
+			cfg:      cfg,
+			filePath: "hello/foo.md",
+			want: ContentFiles{
+				{
+					SourcePath: "hello/foo.md",
+					OriginDir:  "hello",
+					FileName:   "foo",
+					Ext:        ".md",
+					Language:   config.LanguageCodeEnglish,
+					Content:    Markdown(fooMd),
+				},
+				{
+					SourcePath: "hello/foo.md",
+					OriginDir:  "hello",
+					FileName:   "foo",
+					Ext:        ".md",
+					Language:   config.LanguageCodeJapanese,
+					Content:    Markdown(fooMd),
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "ignore rule과 일치하는 파일",
+			cfg:      cfg,
+			filePath: "world/foo.md",
+			want:     nil,
+			wantErr:  false,
+		},
+		{
+			name:     "번역 대상 확장자가 아닌 파일",
+			cfg:      cfg,
+			filePath: "hello/hello.txt",
+			want:     nil,
+			wantErr:  false,
+		},
+		{
+			name: "번역 결과물",
+			cfg: ParserConfig{
+				ContentDir:      "./test_output_content",
+				TargetLanguages: cfg.TargetLanguages,
+				TargetPathRule:  cfg.TargetPathRule,
+			},
+			filePath: "post/hello.ja.md",
+			want:     nil,
+			wantErr:  false,
+		},
+		{
+			name:     "존재하지 않는 파일",
+			cfg:      cfg,
+			filePath: "hello/none.md",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser{
+				cfg: tt.cfg,
+			}
+
+			got, err := p.ParseFile(t.Context(), tt.filePath)
+			assert.Equalf(t, tt.wantErr, err != nil, "parser.ParseFile() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equalf(t, tt.want, got, "parser.ParseFile(%v)", tt.filePath)
+		})
+	}
+}
@@ -0,0 +1,180 @@
+package file
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
+)
+
+// outputScan은 ContentDir의 모든 파일을 번역 결과물과 원본 파일로 나눈 결과입니다.
+type outputScan struct {
+	rule    *pathrule.Rule
+	matcher *pathrule.Matcher
+	// outputs는 front matter에 translated가 true로 설정되어 있고 TargetPathRule과 매칭되는 번역 결과물
+	outputs []sourceFile
+	// sources는 번역 결과물도, 이미 번역된 파일도 아닌 원본 파일
+	sources []sourceFile
+	// expected는 현재 원본 파일들로부터 만들어지는 번역 결과물의 경로
+	expected map[string]bool
+	// matched는 translated 여부와 관계없이 TargetPathRule과 매칭되는 파일의 경로별 파일
+	matched map[string]sourceFile
+}
+
+// orphans는 expected에 포함되지 않는 번역 결과물을 경로 순으로 반환합니다.
+func (s outputScan) orphans() []sourceFile {
+	orphans := slices.DeleteFunc(slices.Clone(s.outputs), func(output sourceFile) bool {
+		return s.expected[output.path]
+	})
+	slices.SortFunc(orphans, func(a, b sourceFile) int {
+		return strings.Compare(a.path, b.path)
+	})
+
+	return orphans
+}
+
+func (p parser) scanOutputs(ctx context.Context) (*outputScan, error) {
+	rule, err := pathrule.Parse(p.cfg.TargetPathRule)
+	if err != nil {
+		return nil, err
+	}
+
+	scan := &outputScan{
+		rule:     rule,
+		matcher:  rule.Matcher(p.cfg.TargetLanguages.Strings()),
+		expected: make(map[string]bool),
+		matched:  make(map[string]sourceFile),
+	}
+
+	// ignoreRules로 제외된 원본도 존재하는 원본이므로 모든 파일을 대상으로 함
+	filePaths, err := p.walkContentFilePaths(true, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, filePath := range filePaths {
+		var (
+			file        []byte
+			frontMatter map[string]any
+		)
+
+		file, err = os.ReadFile(path.Join(p.cfg.ContentDir, filePath))
+		if err != nil {
+			return nil, err
+		}
+
+		if err = parseFrontMatter(file, &frontMatter); err != nil {
+			return nil, err
+		}
+
+		source := sourceFile{path: filepath.ToSlash(filePath), content: file, frontMatter: frontMatter}
+
+		translated, _ := frontMatter["translated"].(bool)
+		if scan.matcher.MatchString(source.path) {
+			scan.matched[source.path] = source
+			if translated {
+				scan.outputs = append(scan.outputs, source)
+			}
+			continue
+		}
+
+		if translated {
+			continue
+		}
+
+		scan.sources = append(scan.sources, source)
+
+		for _, lang := range p.cfg.TargetLanguages {
+			contentFile, err := p.sourceContentFile(source, lang)
+			if err != nil {
+				return nil, err
+			}
+
+			targetFilePath, err := rule.Render(contentFile.PathVars())
+			if err != nil {
+				slog.DebugContext(ctx, "failed to render target path", "path", filePath, "error", err)
+				continue
+			}
+
+			scan.expected[targetFilePath] = true
+		}
+	}
+
+	return scan, nil
+}
+
+// sourceContentFile은 원본 파일을 lang으로 번역하기 위한 ContentFile을 반환합니다.
+func (p parser) sourceContentFile(source sourceFile, lang config.LanguageCode) (ContentFile, error) {
+	fileName, err := FileNameWithoutExtension(source.path)
+	if err != nil {
+		return ContentFile{}, err
+	}
+
+	return ContentFile{
+		SourcePath:  source.path,
+		OriginDir:   p.originDir(source.path),
+		FileName:    fileName,
+		Ext:         filepath.Ext(source.path),
+		Language:    lang,
+		Content:     Markdown(source.content),
+		FrontMatter: source.frontMatter,
+	}, nil
+}
+
+// Orphans는 원본 파일이 삭제되거나 이름이 바뀌어 더 이상 대응하는 원본이 없는 번역 결과물의
+// ContentDir 기준 상대 경로를 반환합니다.
+// front matter에 translated가 true로 설정되어 있고 TargetPathRule과 매칭되는 파일만 대상으로 하며,
+// 현재 원본 파일들로부터 만들어지는 번역 결과물의 경로에 포함되지 않으면 고아 파일로 판단합니다.
+func (p parser) Orphans(ctx context.Context) ([]string, error) {
+	scan, err := p.scanOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var orphans []string
+	for _, orphan := range scan.orphans() {
+		orphans = append(orphans, orphan.path)
+	}
+
+	return orphans, nil
+}
+
+// UnusedSnapshots는 SnapshotDir에 보관된 원본 중 고아 파일이 아닌 번역 결과물의 source_hash가 가리키지 않는 원본의 source_hash를 반환합니다.
+// 고아 파일은 prune으로 함께 삭제되므로, 고아 파일만 가리키는 원본도 사용하지 않는 원본으로 판단합니다.
+func (p parser) UnusedSnapshots(ctx context.Context) ([]string, error) {
+	hashes, err := NewSourceSnapshots(p.cfg.ContentDir).Hashes()
+	if err != nil || len(hashes) == 0 {
+		return nil, err
+	}
+
+	scan, err := p.scanOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orphans := make(map[string]bool)
+	for _, orphan := range scan.orphans() {
+		orphans[orphan.path] = true
+	}
+
+	referenced := make(map[string]bool)
+	for _, output := range scan.outputs {
+		if orphans[output.path] {
+			continue
+		}
+
+		if hash, ok := output.frontMatter["source_hash"].(string); ok {
+			referenced[hash] = true
+		}
+	}
+
+	return slices.DeleteFunc(hashes, func(hash string) bool {
+		return referenced[hash]
+	}), nil
+}
@@ -0,0 +1,133 @@
+package file
+
+import (
+	"context"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/pkg/errors"
+)
+
+// Relocation은 이름이 바뀐 원본 파일에 맞춰 기존 번역 결과물을 옮기는 작업입니다.
+type Relocation struct {
+	// From, To는 ContentDir 기준 번역 결과물의 이동 전, 이동 후 경로입니다.
+	From string
+	To   string
+	// Source는 이름이 바뀐 원본 파일의 ContentDir 기준 경로입니다.
+	Source   string
+	Language config.LanguageCode
+	// TranslationKey는 이름이 바뀐 원본 파일의 translationKey입니다. (ContentFile.TranslationKey 참고)
+	TranslationKey string
+}
+
+// Relocations는 고아가 된 번역 결과물 중 이름이 바뀐 원본 파일을 찾을 수 있는 것들의 이동 작업을 반환합니다.
+// renames는 ContentDir 기준 이전 원본 경로를 키로, 현재 원본 경로를 값으로 하는 map이며(ex. git의 rename 정보),
+// renames로 찾을 수 없다면 번역 결과물 front matter의 source_hash와 내용이 같은 원본 파일을 찾습니다.
+// 이동할 경로에 이미 파일이 있다면 덮어쓰지 않도록 제외합니다.
+func (p parser) Relocations(ctx context.Context, renames map[string]string) ([]Relocation, error) {
+	scan, err := p.scanOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		sourceByPath = make(map[string]sourceFile)
+		// 내용이 같은 원본이 여러 개라면 어떤 원본인지 특정할 수 없으므로 빈 문자열로 표시
+		sourceByHash = make(map[string]string)
+	)
+	for _, source := range scan.sources {
+		sourceByPath[source.path] = source
+
+		hash := SourceHash(Markdown(source.content))
+		if _, ok := sourceByHash[hash]; ok {
+			sourceByHash[hash] = ""
+			continue
+		}
+		sourceByHash[hash] = source.path
+	}
+
+	var (
+		relocations []Relocation
+		reserved    = make(map[string]bool)
+	)
+	for _, orphan := range scan.orphans() {
+		match, ok := scan.matcher.Match(orphan.path)
+		if !ok {
+			continue
+		}
+
+		sourcePath := p.renamedSource(match.FileName, match.Origin, match.Ext, renames)
+		if _, ok = sourceByPath[sourcePath]; !ok {
+			hash, _ := orphan.frontMatter["source_hash"].(string)
+			sourcePath = sourceByHash[hash]
+		}
+
+		source, ok := sourceByPath[sourcePath]
+		if !ok {
+			slog.DebugContext(ctx, "renamed source not found", "path", orphan.path)
+			continue
+		}
+
+		lang := config.LanguageCode(match.Language)
+
+		contentFile, err := p.sourceContentFile(source, lang)
+		if err != nil {
+			return nil, err
+		}
+
+		target, err := scan.rule.Render(contentFile.PathVars())
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to render target path of %s", source.path)
+		}
+
+		if target == orphan.path || reserved[target] {
+			continue
+		}
+		if _, err = os.Stat(path.Join(p.cfg.ContentDir, target)); err == nil {
+			slog.DebugContext(ctx, "skip relocation to existing file", "from", orphan.path, "to", target)
+			continue
+		}
+		reserved[target] = true
+
+		relocations = append(relocations, Relocation{
+			From:           orphan.path,
+			To:             target,
+			Source:         source.path,
+			Language:       lang,
+			TranslationKey: contentFile.TranslationKey(),
+		})
+	}
+
+	return relocations, nil
+}
+
+// renamedSource는 fileName, origin, ext로 특정되는 이전 원본 파일이 renames에서 바뀐 현재 경로를 반환합니다.
+func (p parser) renamedSource(fileName, origin, ext string, renames map[string]string) string {
+	if fileName == "" {
+		return ""
+	}
+
+	olds := make([]string, 0, len(renames))
+	for old := range renames {
+		olds = append(olds, old)
+	}
+	slices.Sort(olds)
+
+	for _, old := range olds {
+		oldFileName, err := FileNameWithoutExtension(old)
+		if err != nil || oldFileName != fileName || p.originDir(old) != origin {
+			continue
+		}
+		if ext != "" && filepath.Ext(old) != ext {
+			continue
+		}
+
+		return filepath.ToSlash(renames[old])
+	}
+
+	return ""
+}
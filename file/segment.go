@@ -0,0 +1,318 @@
+package file
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrMissingPlaceholder   = errors.New("placeholder is missing in translated content")
+	ErrUnknownPlaceholder   = errors.New("unknown placeholder in translated content")
+	ErrDuplicatePlaceholder = errors.New("placeholder is duplicated in translated content")
+)
+
+// IsPlaceholderError는 번역 결과물의 placeholder가 누락, 중복되었거나 알 수 없는 placeholder가 있어 복원하지 못한 에러인지 확인합니다.
+// 모델이 placeholder를 잘못 옮긴 경우이므로 다시 번역을 요청할 수 있습니다.
+func IsPlaceholderError(err error) bool {
+	return errors.Is(err, ErrMissingPlaceholder) || errors.Is(err, ErrUnknownPlaceholder) || errors.Is(err, ErrDuplicatePlaceholder)
+}
+
+// placeholderPattern은 번역 요청 시 마크업 세그먼트를 대신하는 토큰의 패턴입니다.
+// 일반적인 컨텐츠에 등장하지 않는 괄호를 사용해 원문과 충돌하지 않도록 합니다.
+var placeholderPattern = regexp.MustCompile(`⟦(\d+)⟧`)
+
+func placeholder(i int) string {
+	return fmt.Sprintf("⟦%d⟧", i)
+}
+
+// Segment는 컨텐츠의 일부분으로, 번역 대상 텍스트인지 마크업인지를 나타냅니다.
+type Segment struct {
+	Text         string
+	Translatable bool
+}
+
+type Segments []Segment
+
+func (s Segments) String() string {
+	var sb strings.Builder
+	for _, segment := range s {
+		sb.WriteString(segment.Text)
+	}
+
+	return sb.String()
+}
+
+// Mask는 마크업 세그먼트를 placeholder로 치환한 문자열을 반환합니다.
+// 마크업이 없으면 원문과 동일한 문자열이 반환됩니다.
+func (s Segments) Mask() string {
+	var (
+		sb strings.Builder
+		i  int
+	)
+	for _, segment := range s {
+		if segment.Translatable {
+			sb.WriteString(segment.Text)
+			continue
+		}
+
+		sb.WriteString(placeholder(i))
+		i++
+	}
+
+	return sb.String()
+}
+
+func (s Segments) markups() []string {
+	var markups []string
+	for _, segment := range s {
+		if !segment.Translatable {
+			markups = append(markups, segment.Text)
+		}
+	}
+
+	return markups
+}
+
+// Unmask는 번역된 문자열의 placeholder를 원래의 마크업으로 복원합니다.
+// 모든 placeholder가 정확히 한 번씩 등장해야 합니다.
+func (s Segments) Unmask(translated string) (string, error) {
+	var (
+		markups = s.markups()
+		seen    = make([]bool, len(markups))
+		err     error
+	)
+
+	restored := placeholderPattern.ReplaceAllStringFunc(translated, func(token string) string {
+		i, convErr := strconv.Atoi(placeholderPattern.FindStringSubmatch(token)[1])
+		if convErr != nil || i >= len(markups) {
+			err = errors.Wrapf(ErrUnknownPlaceholder, "placeholder: %s", token)
+			return token
+		}
+		if seen[i] {
+			err = errors.Wrapf(ErrDuplicatePlaceholder, "placeholder: %s", token)
+			return token
+		}
+		seen[i] = true
+
+		return markups[i]
+	})
+	if err != nil {
+		return "", err
+	}
+
+	for i, ok := range seen {
+		if !ok {
+			return "", errors.Wrapf(ErrMissingPlaceholder, "placeholder: %s", placeholder(i))
+		}
+	}
+
+	return restored, nil
+}
+
+// Segmenter는 컨텐츠를 번역 대상 텍스트와 마크업으로 나눕니다.
+type Segmenter interface {
+	Segment(content string) Segments
+}
+
+// textGroup은 마크업 규칙 안에서 번역 대상으로 남겨둘 부분을 지정하는 named group 이름입니다.
+const textGroup = "text"
+
+// regexpSegmenter는 정규표현식 규칙으로 마크업을 찾는 Segmenter입니다.
+// 규칙에 "text" named group이 있으면 해당 부분은 번역 대상으로, 나머지는 마크업으로 취급합니다.
+type regexpSegmenter struct {
+	rules []*regexp.Regexp
+	// attributes[rule]이 있으면 rule과 매칭된 마크업 안에서 attributes[rule]의 "text" group도 번역 대상으로 취급합니다.
+	// 속성의 순서와 개수가 정해져 있지 않은 shortcode, HTML 태그에 사용합니다.
+	attributes map[*regexp.Regexp]*regexp.Regexp
+}
+
+type span struct {
+	start, end int
+	rule       int
+	groups     []int
+}
+
+func (r regexpSegmenter) Segment(content string) Segments {
+	var spans []span
+	for i, rule := range r.rules {
+		for _, loc := range rule.FindAllStringSubmatchIndex(content, -1) {
+			if loc[0] == loc[1] {
+				continue
+			}
+
+			groups := textGroups(rule, loc)
+			if attribute, ok := r.attributes[rule]; ok {
+				groups = attributeGroups(attribute, content, loc[0], loc[1])
+			}
+
+			spans = append(spans, span{start: loc[0], end: loc[1], rule: i, groups: groups})
+		}
+	}
+
+	// 시작 위치가 빠른 규칙, 같다면 먼저 선언된 규칙이 우선하며 겹치는 규칙은 버립니다.
+	sort.SliceStable(spans, func(i, j int) bool {
+		if spans[i].start != spans[j].start {
+			return spans[i].start < spans[j].start
+		}
+
+		return spans[i].rule < spans[j].rule
+	})
+
+	var (
+		segments Segments
+		pos      int
+	)
+	for _, sp := range spans {
+		if sp.start < pos {
+			continue
+		}
+
+		segments = segments.add(content[pos:sp.start], true)
+
+		markupStart := sp.start
+		for i := 0; i < len(sp.groups); i += 2 {
+			segments = segments.add(content[markupStart:sp.groups[i]], false)
+			segments = segments.add(content[sp.groups[i]:sp.groups[i+1]], true)
+			markupStart = sp.groups[i+1]
+		}
+		segments = segments.add(content[markupStart:sp.end], false)
+
+		pos = sp.end
+	}
+	segments = segments.add(content[pos:], true)
+
+	return segments
+}
+
+// textGroups는 매칭된 "text" group의 [start, end) 위치를 순서대로 반환합니다.
+func textGroups(rule *regexp.Regexp, loc []int) []int {
+	var groups []int
+	for i, name := range rule.SubexpNames() {
+		if name != textGroup || loc[2*i] < 0 || loc[2*i] == loc[2*i+1] {
+			continue
+		}
+
+		groups = append(groups, loc[2*i], loc[2*i+1])
+	}
+
+	return groups
+}
+
+// attributeGroups는 content[start:end]에서 attribute와 매칭된 "text" group의 [start, end) 위치를 순서대로 반환합니다.
+func attributeGroups(attribute *regexp.Regexp, content string, start, end int) []int {
+	var groups []int
+	for _, loc := range attribute.FindAllStringSubmatchIndex(content[start:end], -1) {
+		for _, group := range textGroups(attribute, loc) {
+			groups = append(groups, start+group)
+		}
+	}
+
+	return groups
+}
+
+// add는 비어있지 않은 세그먼트를 추가하며, 같은 종류가 연속되면 하나로 합칩니다.
+func (s Segments) add(text string, translatable bool) Segments {
+	if text == "" {
+		return s
+	}
+
+	if n := len(s); n > 0 && s[n-1].Translatable == translatable {
+		s[n-1].Text += text
+		return s
+	}
+
+	return append(s, Segment{Text: text, Translatable: translatable})
+}
+
+var (
+	shortcodeRule   = regexp.MustCompile(`(?s)\{\{[<%].*?[%>]\}\}`)
+	htmlCommentRule = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+	// figureRule, imgTagRule은 이미지의 대체 텍스트와 제목만 번역하고 src 등 나머지 속성은 그대로 둡니다.
+	figureRule          = regexp.MustCompile(`(?s)\{\{[<%]\s*figure\b.*?[%>]\}\}`)
+	imgTagRule          = regexp.MustCompile(`(?is)<img\b[^>]*>`)
+	imageAttributesRule = regexp.MustCompile(`\b(?:alt|title|caption|attr)="(?P<text>[^"]*)"`)
+
+	markdownRules = []*regexp.Regexp{
+		regexp.MustCompile("(?ms)^[ \t]*```.*?^[ \t]*```[ \t]*$"),
+		regexp.MustCompile(`(?ms)^[ \t]*~~~.*?^[ \t]*~~~[ \t]*$`),
+		figureRule,
+		shortcodeRule,
+		htmlCommentRule,
+		regexp.MustCompile("`[^`\n]+`"),
+		// ![alt](src "title")
+		regexp.MustCompile(`!\[(?P<text>[^\]\n]*)\]\([^()\s]*(?:\s+"(?P<text>[^"]*)")?\)`),
+		// [text](href "title")
+		regexp.MustCompile(`\]\([^()\s]*(?:\s+"(?P<text>[^"]*)")?\)`),
+		imgTagRule,
+		regexp.MustCompile(`</?[a-zA-Z][^>\n]*>`),
+	}
+
+	htmlRules = []*regexp.Regexp{
+		regexp.MustCompile(`(?is)<script\b.*?</script>`),
+		regexp.MustCompile(`(?is)<style\b.*?</style>`),
+		regexp.MustCompile(`(?is)<pre\b.*?</pre>`),
+		regexp.MustCompile(`(?is)<code\b.*?</code>`),
+		figureRule,
+		shortcodeRule,
+		htmlCommentRule,
+		imgTagRule,
+		regexp.MustCompile(`(?s)</?[a-zA-Z!][^>]*>`),
+	}
+
+	imageAttributes = map[*regexp.Regexp]*regexp.Regexp{
+		figureRule: imageAttributesRule,
+		imgTagRule: imageAttributesRule,
+	}
+
+	asciiDocRules = []*regexp.Regexp{
+		regexp.MustCompile(`(?ms)^----[ \t]*$.*?^----[ \t]*$`),
+		regexp.MustCompile(`(?ms)^\.\.\.\.[ \t]*$.*?^\.\.\.\.[ \t]*$`),
+		regexp.MustCompile(`(?ms)^\+\+\+\+[ \t]*$.*?^\+\+\+\+[ \t]*$`),
+		regexp.MustCompile(`(?ms)^////[ \t]*$.*?^////[ \t]*$`),
+		regexp.MustCompile(`(?m)^//.*$`),
+		regexp.MustCompile(`(?m)^\[[^\]\n]*\][ \t]*$`),
+		regexp.MustCompile(`(?m)^[a-z0-9_-]+::[^\[\n]*\[`),
+		shortcodeRule,
+		regexp.MustCompile("`[^`\n]+`"),
+	}
+
+	orgRules = []*regexp.Regexp{
+		regexp.MustCompile(`(?ims)^[ \t]*#\+begin_(?:src|example|export)\b.*?^[ \t]*#\+end_(?:src|example|export)[ \t]*$`),
+		regexp.MustCompile(`(?im)^[ \t]*#\+(?:title|subtitle|description|summary):[ \t]*(?P<text>.*)$`),
+		regexp.MustCompile(`(?m)^[ \t]*#\+.*$`),
+		regexp.MustCompile(`\[\[[^\]\n]+\]\[(?P<text>[^\]\n]+)\]\]`),
+		regexp.MustCompile(`\[\[[^\]\n]+\]\]`),
+		shortcodeRule,
+	}
+
+	rstRules = []*regexp.Regexp{
+		regexp.MustCompile(`(?m)^\.\. [\w:-]+::.*$`),
+		regexp.MustCompile(`(?m)^\.\. .*$`),
+		regexp.MustCompile("``[^`\n]+``"),
+		regexp.MustCompile(`:[\w-]+:`),
+		regexp.MustCompile(`<[a-zA-Z]+://[^>\n]+>`),
+		shortcodeRule,
+	}
+)
+
+// NewSegmenter는 형식에 맞는 Segmenter를 반환합니다.
+func NewSegmenter(format Format) Segmenter {
+	switch format {
+	case FormatHTML:
+		return regexpSegmenter{rules: htmlRules, attributes: imageAttributes}
+	case FormatAsciiDoc:
+		return regexpSegmenter{rules: asciiDocRules}
+	case FormatOrg:
+		return regexpSegmenter{rules: orgRules}
+	case FormatRST:
+		return regexpSegmenter{rules: rstRules}
+	default:
+		return regexpSegmenter{rules: markdownRules, attributes: imageAttributes}
+	}
+}
@@ -0,0 +1,167 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_regexpSegmenter_Segment(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		content string
+		want    Segments
+	}{
+		{
+			name:    "마크업이 없는 경우",
+			format:  FormatMarkdown,
+			content: "# 안녕, 세계!",
+			want: Segments{
+				{Text: "# 안녕, 세계!", Translatable: true},
+			},
+		},
+		{
+			name:    "Markdown 코드 블록과 링크",
+			format:  FormatMarkdown,
+			content: "[링크](https://example.com) 입니다.\n```go\nfmt.Println(\"안녕\")\n```\n끝",
+			want: Segments{
+				{Text: "[링크", Translatable: true},
+				{Text: "](https://example.com)", Translatable: false},
+				{Text: " 입니다.\n", Translatable: true},
+				{Text: "```go\nfmt.Println(\"안녕\")\n```", Translatable: false},
+				{Text: "\n끝", Translatable: true},
+			},
+		},
+		{
+			name:    "HTML 태그",
+			format:  FormatHTML,
+			content: "<p class=\"a\">안녕</p><pre>code</pre>",
+			want: Segments{
+				{Text: "<p class=\"a\">", Translatable: false},
+				{Text: "안녕", Translatable: true},
+				{Text: "</p><pre>code</pre>", Translatable: false},
+			},
+		},
+		{
+			name:    "Markdown 이미지는 대체 텍스트와 제목만 번역 대상",
+			format:  FormatMarkdown,
+			content: "그림: ![고양이](/img/cat.png \"귀여운 고양이\") [링크](/post/ \"글\")",
+			want: Segments{
+				{Text: "그림: ", Translatable: true},
+				{Text: "![", Translatable: false},
+				{Text: "고양이", Translatable: true},
+				{Text: "](/img/cat.png \"", Translatable: false},
+				{Text: "귀여운 고양이", Translatable: true},
+				{Text: "\")", Translatable: false},
+				{Text: " [링크", Translatable: true},
+				{Text: "](/post/ \"", Translatable: false},
+				{Text: "글", Translatable: true},
+				{Text: "\")", Translatable: false},
+			},
+		},
+		{
+			name:    "figure shortcode와 img 태그는 alt, title, caption만 번역 대상",
+			format:  FormatMarkdown,
+			content: "{{< figure src=\"/img/cat.png\" alt=\"고양이\" caption=\"설명\" >}}\n<img src=\"/img/dog.png\" alt=\"강아지\">",
+			want: Segments{
+				{Text: "{{< figure src=\"/img/cat.png\" alt=\"", Translatable: false},
+				{Text: "고양이", Translatable: true},
+				{Text: "\" caption=\"", Translatable: false},
+				{Text: "설명", Translatable: true},
+				{Text: "\" >}}", Translatable: false},
+				{Text: "\n", Translatable: true},
+				{Text: "<img src=\"/img/dog.png\" alt=\"", Translatable: false},
+				{Text: "강아지", Translatable: true},
+				{Text: "\">", Translatable: false},
+			},
+		},
+		{
+			name:    "Org keyword는 title만 번역 대상",
+			format:  FormatOrg,
+			content: "#+title: 제목\n#+date: 2025-01-01\n본문",
+			want: Segments{
+				{Text: "#+title: ", Translatable: false},
+				{Text: "제목\n", Translatable: true},
+				{Text: "#+date: 2025-01-01", Translatable: false},
+				{Text: "\n본문", Translatable: true},
+			},
+		},
+		{
+			name:    "AsciiDoc 코드 블록",
+			format:  FormatAsciiDoc,
+			content: "= 제목\n\n[source,go]\n----\nfmt.Println()\n----\n",
+			want: Segments{
+				{Text: "= 제목\n\n", Translatable: true},
+				{Text: "[source,go]", Translatable: false},
+				{Text: "\n", Translatable: true},
+				{Text: "----\nfmt.Println()\n----", Translatable: false},
+				{Text: "\n", Translatable: true},
+			},
+		},
+		{
+			name:    "reStructuredText directive",
+			format:  FormatRST,
+			content: ".. note:: 참고\n\n``code`` 입니다.",
+			want: Segments{
+				{Text: ".. note:: 참고", Translatable: false},
+				{Text: "\n\n", Translatable: true},
+				{Text: "``code``", Translatable: false},
+				{Text: " 입니다.", Translatable: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewSegmenter(tt.format).Segment(tt.content)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.content, got.String())
+		})
+	}
+}
+
+func TestSegments_Unmask(t *testing.T) {
+	segments := Segments{
+		{Text: "[링크", Translatable: true},
+		{Text: "](https://example.com)", Translatable: false},
+		{Text: " 입니다.", Translatable: true},
+	}
+
+	tests := []struct {
+		name       string
+		translated string
+		want       string
+		wantErr    bool
+	}{
+		{
+			name:       "성공",
+			translated: "[link⟦0⟧ is here.",
+			want:       "[link](https://example.com) is here.",
+			wantErr:    false,
+		},
+		{
+			name:       "placeholder가 누락된 경우",
+			translated: "[link is here.",
+			wantErr:    true,
+		},
+		{
+			name:       "알 수 없는 placeholder가 있는 경우",
+			translated: "[link⟦0⟧ is ⟦1⟧ here.",
+			wantErr:    true,
+		},
+		{
+			name:       "placeholder가 중복된 경우",
+			translated: "[link⟦0⟧ is⟦0⟧ here.",
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, "[링크⟦0⟧ 입니다.", segments.Mask())
+
+			got, err := segments.Unmask(tt.translated)
+			assert.Equalf(t, tt.wantErr, err != nil, "Unmask() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
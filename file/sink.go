@@ -0,0 +1,244 @@
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Sink는 번역 결과물을 저장하는 대상입니다.
+// 이름은 모두 ContentDir 기준 상대 경로이며, Writer가 사용을 마치면 Close를 호출해야 합니다.
+type Sink interface {
+	WriteFile(name string, data []byte) error
+	// Remove는 이름이 바뀐 원본을 따라 옮겨진 번역 결과물의 이전 경로를 삭제하며, 삭제할 수 없는 Sink는 무시합니다.
+	Remove(name string) error
+	Close() error
+}
+
+// NewSink는 output에 따라 번역 결과물을 저장할 Sink를 반환합니다.
+//   - 빈 문자열: contentDir에 직접 저장 (in-place)
+//   - .tar, .tar.gz, .tgz, .zip으로 끝나는 경로: 아카이브 파일로 저장
+//   - 그 외: contentDir과 같은 구조로 output 디렉터리에 저장
+func NewSink(contentDir, output string, fileMode, dirMode os.FileMode) (Sink, error) {
+	if output == "" {
+		return NewDirSink(contentDir, fileMode, dirMode), nil
+	}
+
+	if IsArchive(output) {
+		return NewArchiveSink(output, fileMode)
+	}
+
+	return NewDirSink(output, fileMode, dirMode), nil
+}
+
+// dirSink는 root 디렉터리에 번역 결과물을 저장합니다.
+// root가 ContentDir이면 기존처럼 Hugo 컨텐츠 디렉터리에, 아니라면 같은 구조의 별도 디렉터리에 저장합니다.
+type dirSink struct {
+	root     string
+	fileMode os.FileMode
+	dirMode  os.FileMode
+}
+
+func NewDirSink(root string, fileMode, dirMode os.FileMode) Sink {
+	if fileMode == 0 {
+		fileMode = DefaultFileMode
+	}
+	if dirMode == 0 {
+		dirMode = DefaultDirMode
+	}
+
+	return &dirSink{
+		root:     root,
+		fileMode: fileMode,
+		dirMode:  dirMode,
+	}
+}
+
+func (s *dirSink) WriteFile(name string, data []byte) error {
+	path := filepath.Join(s.root, name)
+
+	if err := os.MkdirAll(filepath.Dir(path), s.dirMode); err != nil {
+		return errors.Wrap(err, "failed to create parent directory")
+	}
+
+	// 기존 파일이 있다면 파일 모드를 유지하며, 중간에 중단되어도 파일이 손상되지 않도록 임시 파일을 거쳐 씀
+	return WriteFileAtomic(path, data, fileMode(path, s.fileMode))
+}
+
+func (s *dirSink) Remove(name string) error {
+	if err := os.Remove(filepath.Join(s.root, name)); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to remove %s", name)
+	}
+
+	return nil
+}
+
+func (s *dirSink) Close() error {
+	return nil
+}
+
+const (
+	archiveTar   = "tar"
+	archiveTarGz = "tar.gz"
+	archiveZip   = "zip"
+)
+
+// IsArchive는 path가 번역 결과물을 아카이브(.tar, .tar.gz, .tgz, .zip)로 저장하는 --output 경로인지 확인합니다.
+func IsArchive(path string) bool {
+	return archiveFormatOf(path) != ""
+}
+
+func archiveFormatOf(path string) string {
+	switch lower := strings.ToLower(path); {
+	case strings.HasSuffix(lower, ".tar.gz"), strings.HasSuffix(lower, ".tgz"):
+		return archiveTarGz
+	case strings.HasSuffix(lower, ".tar"):
+		return archiveTar
+	case strings.HasSuffix(lower, ".zip"):
+		return archiveZip
+	default:
+		return ""
+	}
+}
+
+// archiveSink는 번역 결과물을 tar(.tar, .tar.gz, .tgz) 또는 zip 아카이브에 저장합니다.
+// 아카이브 파일은 처음 쓸 때 만들어지고 Close를 호출해야 완성되며,
+// 같은 경로에 여러 번 쓰면 마지막 내용이 아카이브 뒤쪽에 추가됩니다.
+type archiveSink struct {
+	path     string
+	format   string
+	fileMode os.FileMode
+
+	mu   sync.Mutex
+	file *os.File
+	gzip *gzip.Writer
+	tar  *tar.Writer
+	zip  *zip.Writer
+}
+
+func NewArchiveSink(path string, fileMode os.FileMode) (Sink, error) {
+	if fileMode == 0 {
+		fileMode = DefaultFileMode
+	}
+
+	format := archiveFormatOf(path)
+	if format == "" {
+		return nil, errors.Errorf("unsupported archive format: %s", path)
+	}
+
+	return &archiveSink{
+		path:     path,
+		format:   format,
+		fileMode: fileMode,
+	}, nil
+}
+
+func (s *archiveSink) open() error {
+	if s.file != nil {
+		return nil
+	}
+
+	f, err := os.Create(s.path)
+	if err != nil {
+		return errors.Wrap(err, "failed to create archive file")
+	}
+
+	s.file = f
+	switch s.format {
+	case archiveTarGz:
+		s.gzip = gzip.NewWriter(f)
+		s.tar = tar.NewWriter(s.gzip)
+	case archiveTar:
+		s.tar = tar.NewWriter(f)
+	case archiveZip:
+		s.zip = zip.NewWriter(f)
+	}
+
+	return nil
+}
+
+func (s *archiveSink) WriteFile(name string, data []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.open(); err != nil {
+		return err
+	}
+
+	name = filepath.ToSlash(name)
+
+	var (
+		w   io.Writer
+		err error
+	)
+	if s.zip != nil {
+		header := &zip.FileHeader{
+			Name:     name,
+			Method:   zip.Deflate,
+			Modified: time.Now(),
+		}
+		header.SetMode(s.fileMode)
+
+		w, err = s.zip.CreateHeader(header)
+	} else {
+		err = s.tar.WriteHeader(&tar.Header{
+			Name:    name,
+			Mode:    int64(s.fileMode),
+			Size:    int64(len(data)),
+			ModTime: time.Now(),
+		})
+		w = s.tar
+	}
+	if err != nil {
+		return errors.Wrapf(err, "failed to add %s to archive", name)
+	}
+
+	if _, err = w.Write(data); err != nil {
+		return errors.Wrapf(err, "failed to write %s to archive", name)
+	}
+
+	return nil
+}
+
+// Remove는 아카이브에 이미 쓴 파일을 지울 수 없으므로 무시합니다.
+func (s *archiveSink) Remove(string) error {
+	return nil
+}
+
+func (s *archiveSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// 아무것도 쓰지 않았다면 아카이브 파일을 만들지 않음
+	if s.file == nil {
+		return nil
+	}
+
+	var closers []io.Closer
+	if s.zip != nil {
+		closers = append(closers, s.zip)
+	}
+	if s.tar != nil {
+		closers = append(closers, s.tar)
+	}
+	if s.gzip != nil {
+		closers = append(closers, s.gzip)
+	}
+	closers = append(closers, s.file)
+
+	for _, c := range closers {
+		if err := c.Close(); err != nil {
+			return errors.Wrap(err, "failed to close archive")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,180 @@
+package file
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSink(t *testing.T) {
+	dir := t.TempDir()
+
+	tests := []struct {
+		name    string
+		output  string
+		want    any
+		wantErr bool
+	}{
+		{
+			name:    "in-place",
+			output:  "",
+			want:    &dirSink{},
+			wantErr: false,
+		},
+		{
+			name:    "디렉터리",
+			output:  filepath.Join(dir, "out"),
+			want:    &dirSink{},
+			wantErr: false,
+		},
+		{
+			name:    "tar.gz 아카이브",
+			output:  filepath.Join(dir, "out.tar.gz"),
+			want:    &archiveSink{},
+			wantErr: false,
+		},
+		{
+			name:    "zip 아카이브",
+			output:  filepath.Join(dir, "out.zip"),
+			want:    &archiveSink{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewSink(dir, tt.output, 0, 0)
+			assert.Equalf(t, tt.wantErr, err != nil, "NewSink() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			assert.IsType(t, tt.want, got)
+			assert.NoError(t, got.Close())
+		})
+	}
+}
+
+func Test_dirSink(t *testing.T) {
+	var (
+		contentDir = t.TempDir()
+		mirrorDir  = t.TempDir()
+	)
+	if err := os.WriteFile(filepath.Join(contentDir, "a.en.md"), []byte("# A"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := NewDirSink(mirrorDir, 0, 0)
+	assert.NoError(t, s.WriteFile("post/b.en.md", []byte("# B")))
+	// 미러 디렉터리에 없는 파일은 무시하고, ContentDir의 파일은 건드리지 않음
+	assert.NoError(t, s.Remove("a.en.md"))
+	assert.NoError(t, s.Close())
+
+	content, err := os.ReadFile(filepath.Join(mirrorDir, "post", "b.en.md"))
+	assert.NoError(t, err)
+	assert.Equal(t, "# B", string(content))
+	assert.FileExists(t, filepath.Join(contentDir, "a.en.md"))
+	assert.NoFileExists(t, filepath.Join(contentDir, "post", "b.en.md"))
+}
+
+func Test_archiveSink(t *testing.T) {
+	files := map[string]string{
+		"post/a.en.md": "# A",
+		"post/b.ja.md": "# B",
+	}
+
+	read := map[string]func(t *testing.T, path string) map[string]string{
+		"out.tar.gz": func(t *testing.T, path string) map[string]string {
+			f, err := os.Open(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			gz, err := gzip.NewReader(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			results := make(map[string]string)
+			tr := tar.NewReader(gz)
+			for {
+				header, err := tr.Next()
+				if err == io.EOF {
+					break
+				}
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				content, err := io.ReadAll(tr)
+				if err != nil {
+					t.Fatal(err)
+				}
+				results[header.Name] = string(content)
+			}
+
+			return results
+		},
+		"out.zip": func(t *testing.T, path string) map[string]string {
+			zr, err := zip.OpenReader(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer zr.Close()
+
+			results := make(map[string]string)
+			for _, f := range zr.File {
+				rc, err := f.Open()
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				content, err := io.ReadAll(rc)
+				_ = rc.Close()
+				if err != nil {
+					t.Fatal(err)
+				}
+				results[f.Name] = string(content)
+			}
+
+			return results
+		},
+	}
+
+	for name, readArchive := range read {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+
+			s, err := NewArchiveSink(path, 0)
+			assert.NoError(t, err)
+			for fileName, content := range files {
+				assert.NoError(t, s.WriteFile(fileName, []byte(content)))
+			}
+			assert.NoError(t, s.Close())
+
+			assert.Equal(t, files, readArchive(t, path))
+		})
+	}
+}
+
+func Test_archiveSink_Error(t *testing.T) {
+	_, err := NewArchiveSink(filepath.Join(t.TempDir(), "out.rar"), 0)
+	assert.Error(t, err)
+
+	// 아무것도 쓰지 않으면 아카이브 파일을 만들지 않음
+	path := filepath.Join(t.TempDir(), "out.zip")
+	s, err := NewArchiveSink(path, 0)
+	assert.NoError(t, err)
+	assert.NoError(t, s.Close())
+	assert.NoFileExists(t, path)
+
+	s, err = NewArchiveSink(filepath.Join(t.TempDir(), "none", "out.zip"), 0)
+	assert.NoError(t, err)
+	assert.Error(t, s.WriteFile("a.en.md", []byte("# A")))
+}
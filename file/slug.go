@@ -0,0 +1,160 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
+	"golang.org/x/text/unicode/norm"
+)
+
+// Slugify는 s를 소문자로 바꾸고 발음 구별 기호를 뺀 뒤, 영문자와 숫자([a-z0-9])가 아닌 부분을 하이픈(-) 하나로 바꾼 URL slug를 반환합니다.
+// 라틴 문자가 아닌 문자는 남기지 않으므로, 다른 문자를 쓰는 언어는 로마자로 옮긴 뒤 사용해야 합니다.
+// ex) "Hello, World!" -> "hello-world", "Café Crème" -> "cafe-creme"
+func Slugify(s string) string {
+	var (
+		sb     strings.Builder
+		hyphen bool
+	)
+	for _, r := range norm.NFD.String(strings.ToLower(s)) {
+		if unicode.Is(unicode.Mn, r) {
+			continue
+		}
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') {
+			hyphen = sb.Len() > 0
+			continue
+		}
+
+		if hyphen {
+			sb.WriteRune('-')
+			hyphen = false
+		}
+		sb.WriteRune(r)
+	}
+
+	return sb.String()
+}
+
+// Title은 번역 결과물 front matter의 title을 반환하며, 없다면 원본 파일의 title, 파일 이름 순으로 반환합니다.
+func (c ContentFile) Title() string {
+	var translated map[string]any
+	if err := parseFrontMatter([]byte(c.Translated), &translated); err == nil {
+		if title, ok := translated["title"].(string); ok && title != "" {
+			return title
+		}
+	}
+
+	if title, ok := c.FrontMatter["title"].(string); ok && title != "" {
+		return title
+	}
+
+	return c.FileName
+}
+
+// PreviousSlug는 이미 있는 번역 결과물 front matter의 slug를 반환하며, 없다면 빈 문자열을 반환합니다.
+func (c ContentFile) PreviousSlug() string {
+	var previous map[string]any
+	if err := parseFrontMatter([]byte(c.Previous), &previous); err != nil {
+		return ""
+	}
+
+	slug, _ := previous["slug"].(string)
+
+	return slug
+}
+
+// pageName은 slug가 없을 때 Hugo가 URL에 사용하는 페이지 이름으로, 페이지 번들(index, _index)이면 디렉터리 이름입니다.
+func pageName(originDir, fileName string) string {
+	if fileName == "index" || fileName == "_index" {
+		return path.Base(path.Clean("/" + originDir))
+	}
+
+	return fileName
+}
+
+// SlugRegistry는 번역 대상 언어의 section별로 사용 중인 slug를 관리하여 번역 결과물의 URL이 겹치지 않게 합니다.
+type SlugRegistry struct {
+	rule *pathrule.Rule
+
+	mu sync.Mutex
+	// owners는 언어, section, slug별로 해당 slug를 사용하는 번역 결과물의 경로
+	owners map[string]string
+}
+
+func slugKey(lang, section, slug string) string {
+	return strings.Join([]string{lang, section, slug}, "\x00")
+}
+
+// SlugRegistry는 이미 있는 번역 결과물의 slug(없다면 페이지 이름)를 사용 중으로 등록한 SlugRegistry를 만듭니다.
+func (p parser) SlugRegistry(ctx context.Context) (*SlugRegistry, error) {
+	scan, err := p.scanOutputs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &SlugRegistry{
+		rule:   scan.rule,
+		owners: make(map[string]string),
+	}
+
+	for _, output := range scan.outputs {
+		match, ok := scan.matcher.Match(output.path)
+		if !ok {
+			continue
+		}
+
+		slug, _ := output.frontMatter["slug"].(string)
+		if slug == "" {
+			slug = pageName(match.Origin, match.FileName)
+		}
+
+		if slug = Slugify(slug); slug != "" {
+			r.owners[slugKey(match.Language, match.Section(), slug)] = output.path
+		}
+	}
+
+	return r, nil
+}
+
+// Reserve는 contentFile의 번역 결과물이 slug를 사용하도록 등록하고, 등록한 slug를 반환합니다.
+// 같은 언어, 같은 section의 다른 번역 결과물이 이미 slug를 사용 중이면 "-2", "-3", ...을 붙입니다.
+// slug에 URL에 사용할 수 있는 문자가 없으면 페이지 이름을 사용하며, 페이지 이름에도 없다면 slug를 등록하지 않고 빈 문자열을 반환합니다.
+func (r *SlugRegistry) Reserve(contentFile ContentFile, slug string) (string, error) {
+	vars := contentFile.PathVars()
+
+	target, err := r.rule.Render(vars)
+	if err != nil {
+		return "", err
+	}
+
+	base := Slugify(slug)
+	if base == "" {
+		base = Slugify(pageName(contentFile.OriginDir, contentFile.FileName))
+	}
+	if base == "" {
+		return "", nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for n := 1; ; n++ {
+		candidate := base
+		if n > 1 {
+			candidate = fmt.Sprintf("%s-%d", base, n)
+		}
+
+		key := slugKey(contentFile.Language.String(), vars.Section(), candidate)
+		if owner, ok := r.owners[key]; ok && owner != target {
+			continue
+		}
+
+		r.owners[key] = target
+
+		return candidate, nil
+	}
+}
@@ -0,0 +1,160 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSlugify(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want string
+	}{
+		{
+			name: "공백과 문장 부호는 하이픈으로",
+			s:    "Hello, World!",
+			want: "hello-world",
+		},
+		{
+			name: "앞뒤와 연속된 구분자는 제거",
+			s:    "  --Go 1.24 Release--  ",
+			want: "go-1-24-release",
+		},
+		{
+			name: "문자가 없는 경우",
+			s:    "!!!",
+			want: "",
+		},
+		{
+			name: "발음 구별 기호는 제거",
+			s:    "Café Crème",
+			want: "cafe-creme",
+		},
+		{
+			name: "라틴 문자가 아닌 문자는 제거",
+			s:    "안녕 World",
+			want: "world",
+		},
+		{
+			name: "라틴 문자가 없는 경우",
+			s:    "안녕",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Slugify(tt.s))
+		})
+	}
+}
+
+func TestSlugRegistry_Reserve(t *testing.T) {
+	p := parser{
+		cfg: ParserConfig{
+			ContentDir:      "test_slug_content",
+			SourceLanguage:  config.LanguageCodeKorean,
+			TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish, config.LanguageCodeJapanese},
+			TargetPathRule:  "{origin}/{fileName}.{language}.md",
+		},
+	}
+
+	tests := []struct {
+		name string
+		// reserved는 이번 실행에서 먼저 slug를 등록한 번역 결과물
+		reserved    *ContentFile
+		contentFile ContentFile
+		slug        string
+		want        string
+	}{
+		{
+			name:        "다른 번역 결과물의 slug와 겹치는 경우",
+			contentFile: ContentFile{OriginDir: "post", FileName: "bar", Language: config.LanguageCodeEnglish},
+			slug:        "Hello",
+			want:        "hello-2",
+		},
+		{
+			name:        "자신의 slug는 그대로 사용",
+			contentFile: ContentFile{OriginDir: "post", FileName: "foo", Language: config.LanguageCodeEnglish},
+			slug:        "hello",
+			want:        "hello",
+		},
+		{
+			name:        "slug가 없는 번역 결과물의 페이지 이름과 겹치는 경우",
+			contentFile: ContentFile{OriginDir: "post", FileName: "foo", Language: config.LanguageCodeEnglish},
+			slug:        "bar",
+			want:        "bar-2",
+		},
+		{
+			name:        "다른 언어, 다른 section은 겹치지 않음",
+			contentFile: ContentFile{OriginDir: "docs", FileName: "baz", Language: config.LanguageCodeEnglish},
+			slug:        "hello",
+			want:        "hello",
+		},
+		{
+			name:        "이번 실행에서 먼저 등록된 slug와 겹치는 경우",
+			reserved:    &ContentFile{OriginDir: "docs", FileName: "baz", Language: config.LanguageCodeJapanese},
+			contentFile: ContentFile{OriginDir: "docs/guide", FileName: "qux", Language: config.LanguageCodeJapanese},
+			slug:        "konnichiwa",
+			want:        "konnichiwa-2",
+		},
+		{
+			name:        "URL에 사용할 수 있는 문자가 없으면 페이지 이름",
+			contentFile: ContentFile{OriginDir: "post/qux", FileName: "index", Language: config.LanguageCodeJapanese},
+			slug:        "???",
+			want:        "qux",
+		},
+		{
+			name:        "페이지 이름에도 URL에 사용할 수 있는 문자가 없으면 등록하지 않음",
+			contentFile: ContentFile{OriginDir: "post/안녕", FileName: "index", Language: config.LanguageCodeJapanese},
+			slug:        "???",
+			want:        "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := p.SlugRegistry(t.Context())
+			assert.NoError(t, err)
+
+			if tt.reserved != nil {
+				_, err = r.Reserve(*tt.reserved, tt.slug)
+				assert.NoError(t, err)
+			}
+
+			got, err := r.Reserve(tt.contentFile, tt.slug)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestContentFile_PreviousSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous Markdown
+		want     string
+	}{
+		{
+			name:     "이전 번역 결과물의 slug",
+			previous: "---\ntitle: Hello\nslug: こんにちは\n---\n\nbody\n",
+			want:     "こんにちは",
+		},
+		{
+			name:     "slug가 없는 경우",
+			previous: "---\ntitle: Hello\n---\n\nbody\n",
+			want:     "",
+		},
+		{
+			name:     "이전 번역 결과물이 없는 경우",
+			previous: "",
+			want:     "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ContentFile{Previous: tt.previous}.PreviousSlug())
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package file
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// SnapshotDir은 content 디렉터리 안에서 번역한 원본의 내용을 보관하는 디렉터리입니다.
+// journal과 같은 숨김 디렉터리 아래에 있으므로 번역 대상과 감시 대상에서 제외됩니다.
+const SnapshotDir = ".hugo-ai-translator/sources"
+
+// SourceSnapshots는 번역한 원본의 내용을 source_hash별로 보관하여,
+// 원본이 수정되었을 때 이전 번역 결과물이 어떤 원본을 번역한 것인지 알 수 있게 합니다.
+type SourceSnapshots struct {
+	dir string
+}
+
+// NewSourceSnapshots는 contentDir의 SnapshotDir에 원본을 보관하는 SourceSnapshots를 만듭니다.
+func NewSourceSnapshots(contentDir string) *SourceSnapshots {
+	return &SourceSnapshots{
+		dir: filepath.Join(contentDir, filepath.FromSlash(SnapshotDir)),
+	}
+}
+
+// Save는 content를 source_hash를 이름으로 하는 파일에 저장하며, 이미 있다면 다시 쓰지 않습니다.
+func (s *SourceSnapshots) Save(content Markdown) error {
+	snapshotPath := filepath.Join(s.dir, SourceHash(content))
+	if _, err := os.Stat(snapshotPath); err == nil {
+		return nil
+	}
+
+	if err := os.MkdirAll(s.dir, DefaultDirMode); err != nil {
+		return errors.Wrap(err, "failed to create snapshot directory")
+	}
+
+	if err := WriteFileAtomic(snapshotPath, []byte(content), DefaultFileMode); err != nil {
+		return errors.Wrap(err, "failed to write source snapshot")
+	}
+
+	return nil
+}
+
+// Load는 source_hash가 hash인 원본의 내용을 반환하며, 보관하지 않은 원본이면 빈 문자열을 반환합니다.
+func (s *SourceSnapshots) Load(hash string) (Markdown, error) {
+	// hash는 번역 결과물의 front matter에서 읽은 값이므로 hex 문자열이 아니면 보관하지 않은 원본으로 간주
+	if _, err := hex.DecodeString(hash); hash == "" || err != nil {
+		return "", nil
+	}
+
+	content, err := os.ReadFile(filepath.Join(s.dir, hash))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to read source snapshot %s", hash)
+	}
+
+	return Markdown(content), nil
+}
+
+// Hashes는 보관된 원본들의 source_hash를 이름 순으로 반환하며, 보관된 원본이 없다면 nil을 반환합니다.
+func (s *SourceSnapshots) Hashes() ([]string, error) {
+	entries, err := os.ReadDir(s.dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read snapshot directory")
+	}
+
+	var hashes []string
+	for _, entry := range entries {
+		// 저장 중인 임시 파일처럼 hex 문자열이 아닌 이름은 보관된 원본이 아님
+		if _, err = hex.DecodeString(entry.Name()); entry.IsDir() || err != nil {
+			continue
+		}
+
+		hashes = append(hashes, entry.Name())
+	}
+
+	return hashes, nil
+}
+
+// Remove는 source_hash가 hash인 원본을 삭제하며, 보관하지 않은 원본이면 아무것도 하지 않습니다.
+func (s *SourceSnapshots) Remove(hash string) error {
+	if _, err := hex.DecodeString(hash); hash == "" || err != nil {
+		return nil
+	}
+
+	if err := os.Remove(filepath.Join(s.dir, hash)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return errors.Wrapf(err, "failed to remove source snapshot %s", hash)
+	}
+
+	return nil
+}
@@ -0,0 +1,235 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSourceSnapshots(t *testing.T) {
+	snapshots := NewSourceSnapshots(t.TempDir())
+
+	content := Markdown("---\ntitle: 안녕\n---\n# 안녕")
+	assert.NoError(t, snapshots.Save(content))
+	// 이미 보관한 원본은 다시 저장해도 에러가 나지 않음
+	assert.NoError(t, snapshots.Save(content))
+
+	tests := []struct {
+		name string
+		hash string
+		want Markdown
+	}{
+		{
+			name: "보관한 원본",
+			hash: SourceHash(content),
+			want: content,
+		},
+		{
+			name: "보관하지 않은 원본",
+			hash: SourceHash("# 다른 원본"),
+			want: "",
+		},
+		{
+			name: "hex 문자열이 아닌 hash",
+			hash: "../journal.json",
+			want: "",
+		},
+		{
+			name: "빈 hash",
+			hash: "",
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := snapshots.Load(tt.hash)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_parser_Parse_Modified(t *testing.T) {
+	var (
+		oldSource = Markdown("---\ntitle: 안녕\n---\n# 안녕\n\n첫 문단")
+		newSource = Markdown("---\ntitle: 안녕\n---\n# 안녕\n\n고친 문단")
+	)
+
+	tests := []struct {
+		name               string
+		translated         string
+		snapshot           bool
+		updateStale        bool
+		want               bool
+		wantPreviousSource Markdown
+	}{
+		{
+			name:               "원본이 수정되면 이전 원본과 함께 다시 번역",
+			translated:         "---\nsource_hash: " + SourceHash(oldSource) + "\ntranslated: true\n---\n# Hello",
+			snapshot:           true,
+			updateStale:        true,
+			want:               true,
+			wantPreviousSource: oldSource,
+		},
+		{
+			name:               "이전 원본이 보관되지 않았어도 다시 번역",
+			translated:         "---\nsource_hash: " + SourceHash(oldSource) + "\ntranslated: true\n---\n# Hello",
+			updateStale:        true,
+			want:               true,
+			wantPreviousSource: "",
+		},
+		{
+			name:       "UpdateStale이 아니면 원본이 수정되어도 건너뜀",
+			translated: "---\nsource_hash: " + SourceHash(oldSource) + "\ntranslated: true\n---\n# Hello",
+			snapshot:   true,
+			want:       false,
+		},
+		{
+			name:        "원본이 수정되지 않았으면 건너뜀",
+			translated:  "---\nsource_hash: " + SourceHash(newSource) + "\ntranslated: true\n---\n# Hello",
+			updateStale: true,
+			want:        false,
+		},
+		{
+			name:        "source_hash가 없으면 건너뜀",
+			translated:  "---\ntranslated: true\n---\n# Hello",
+			updateStale: true,
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(contentDir, "post"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(contentDir, "post", "foo.md"), []byte(newSource), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(contentDir, "post", "foo.en.md"), []byte(tt.translated), 0o644); err != nil {
+				t.Fatal(err)
+			}
+			if tt.snapshot {
+				if err := NewSourceSnapshots(contentDir).Save(oldSource); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			p := NewParser(ParserConfig{
+				ContentDir:      contentDir,
+				TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish},
+				TargetPathRule:  "{origin}/{fileName}.{language}.md",
+				SourceLanguage:  config.LanguageCodeKorean,
+				Extensions:      []string{".md"},
+				UpdateStale:     tt.updateStale,
+			})
+
+			got, err := p.Parse(t.Context())
+			assert.NoError(t, err)
+
+			if !tt.want {
+				assert.Empty(t, got)
+				return
+			}
+
+			if assert.Len(t, got, 1) {
+				assert.Equal(t, newSource, got[0].Content)
+				assert.Equal(t, Markdown(tt.translated), got[0].Previous)
+				assert.Equal(t, tt.wantPreviousSource, got[0].PreviousSource)
+			}
+		})
+	}
+}
+
+func Test_writer_Write_Snapshots(t *testing.T) {
+	contentDir := t.TempDir()
+	snapshots := NewSourceSnapshots(contentDir)
+
+	w := NewWriter(WriterConfig{
+		ContentDir:     contentDir,
+		TargetPathRule: "{origin}/{fileName}.{language}.md",
+		Snapshots:      snapshots,
+	})
+
+	content := Markdown("# 안녕")
+	err := w.Write(t.Context(), ContentFile{
+		SourcePath: "post/foo.md",
+		FileName:   "foo",
+		OriginDir:  "post",
+		Language:   config.LanguageCodeEnglish,
+		Content:    content,
+		Translated: "# Hello",
+	})
+	assert.NoError(t, err)
+
+	got, err := snapshots.Load(SourceHash(content))
+	assert.NoError(t, err)
+	assert.Equal(t, content, got)
+}
+
+func Test_parser_UnusedSnapshots(t *testing.T) {
+	var (
+		source       = Markdown("---\ntitle: 안녕\n---\n# 안녕")
+		oldSource    = Markdown("---\ntitle: 안녕\n---\n# 예전 원본")
+		orphanSource = Markdown("---\ntitle: 삭제\n---\n# 삭제된 원본")
+		unused       = Markdown("# 아무도 가리키지 않는 원본")
+	)
+
+	contentDir := t.TempDir()
+	for name, content := range map[string]string{
+		// 원본이 수정되어 번역 결과물은 이전 원본을 가리킴
+		"post/foo.md":    source.String(),
+		"post/foo.en.md": "---\ntranslated: true\nsource_hash: " + SourceHash(oldSource) + "\n---\n# Old",
+		// 원본이 삭제된 고아 파일
+		"post/bar.en.md": "---\ntranslated: true\nsource_hash: " + SourceHash(orphanSource) + "\n---\n# Deleted",
+	} {
+		path := filepath.Join(contentDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	snapshots := NewSourceSnapshots(contentDir)
+	for _, content := range []Markdown{source, oldSource, orphanSource, unused} {
+		assert.NoError(t, snapshots.Save(content))
+	}
+	// 저장 중인 임시 파일은 보관된 원본이 아님
+	if err := os.WriteFile(filepath.Join(contentDir, filepath.FromSlash(SnapshotDir), ".tmp-1"), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	p := parser{
+		cfg: ParserConfig{
+			ContentDir:      contentDir,
+			TargetLanguages: config.LanguageCodes{config.LanguageCodeEnglish},
+			TargetPathRule:  "{origin}/{fileName}.{language}.md",
+			SourceLanguage:  config.LanguageCodeKorean,
+		},
+	}
+
+	got, err := p.UnusedSnapshots(t.Context())
+	assert.NoError(t, err)
+
+	want := []string{SourceHash(source), SourceHash(orphanSource), SourceHash(unused)}
+	slices.Sort(want)
+	assert.Equal(t, want, got)
+
+	for _, hash := range got {
+		assert.NoError(t, snapshots.Remove(hash))
+	}
+	hashes, err := snapshots.Hashes()
+	assert.NoError(t, err)
+	assert.Equal(t, []string{SourceHash(oldSource)}, hashes)
+
+	// 보관된 원본이 없는 경우
+	got, err = parser{cfg: ParserConfig{ContentDir: t.TempDir()}}.UnusedSnapshots(t.Context())
+	assert.NoError(t, err)
+	assert.Empty(t, got)
+}
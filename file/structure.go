@@ -0,0 +1,137 @@
+package file
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/extension"
+	extast "github.com/yuin/goldmark/extension/ast"
+	"github.com/yuin/goldmark/text"
+)
+
+var ErrStructureMismatch = errors.New("translated content structure does not match source")
+
+// Structure는 Markdown 문서에서 번역하더라도 바뀌면 안 되는 구조입니다.
+type Structure struct {
+	// Headings는 문서 순서대로의 제목 수준입니다.
+	Headings []int
+	// Lists는 문서 순서대로의 목록별 항목 수입니다.
+	Lists []int
+	// Tables는 문서 순서대로의 표별 "행x열" 크기입니다.
+	Tables     []string
+	CodeBlocks int
+	// Links, Images는 링크와 이미지의 대상이며, 순서는 번역하면서 바뀔 수 있으므로 정렬되어 있습니다.
+	Links     []string
+	Images    []string
+	Footnotes int
+}
+
+var (
+	markdown = goldmark.New(goldmark.WithExtensions(extension.Table, extension.Footnote))
+
+	frontMatterRule = regexp.MustCompile(`(?s)\A(?:---\r?\n.*?\r?\n---|\+\+\+\r?\n.*?\r?\n\+\+\+)[ \t]*(?:\r?\n|\z)`)
+
+	// imageSourceRule은 figure shortcode, img 태그에서 goldmark가 이미지로 보지 않는 src 속성입니다.
+	imageSourceRule = regexp.MustCompile(`\bsrc="([^"]*)"`)
+)
+
+// ParseStructure는 front matter를 제외한 Markdown 본문의 구조를 반환합니다.
+func ParseStructure(content Markdown) Structure {
+	source := []byte(frontMatterRule.ReplaceAllString(content.String(), ""))
+
+	var s Structure
+	_ = ast.Walk(markdown.Parser().Parse(text.NewReader(source)), func(n ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch node := n.(type) {
+		case *ast.Heading:
+			s.Headings = append(s.Headings, node.Level)
+		case *ast.List:
+			s.Lists = append(s.Lists, node.ChildCount())
+		case *extast.Table:
+			var columns int
+			if header := node.FirstChild(); header != nil {
+				columns = header.ChildCount()
+			}
+			s.Tables = append(s.Tables, fmt.Sprintf("%dx%d", node.ChildCount(), columns))
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			s.CodeBlocks++
+		case *ast.Link:
+			s.Links = append(s.Links, string(node.Destination))
+		case *ast.AutoLink:
+			s.Links = append(s.Links, string(node.URL(source)))
+		case *ast.Image:
+			s.Images = append(s.Images, string(node.Destination))
+		case *extast.Footnote:
+			s.Footnotes++
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	for _, rule := range []*regexp.Regexp{figureRule, imgTagRule} {
+		for _, match := range rule.FindAllString(string(source), -1) {
+			if groups := imageSourceRule.FindStringSubmatch(match); groups != nil {
+				s.Images = append(s.Images, groups[1])
+			}
+		}
+	}
+
+	slices.Sort(s.Links)
+	slices.Sort(s.Images)
+
+	return s
+}
+
+// Mismatches는 s를 원본으로 보고 translated와 다른 구조를 사람이 읽을 수 있는 문장으로 반환합니다.
+func (s Structure) Mismatches(translated Structure) []string {
+	var mismatches []string
+	mismatch := func(name string, source, translated any) {
+		mismatches = append(mismatches, fmt.Sprintf("%s: source %v, translated %v", name, source, translated))
+	}
+
+	if !slices.Equal(s.Headings, translated.Headings) {
+		mismatch("heading levels", s.Headings, translated.Headings)
+	}
+	if !slices.Equal(s.Lists, translated.Lists) {
+		mismatch("list items", s.Lists, translated.Lists)
+	}
+	if !slices.Equal(s.Tables, translated.Tables) {
+		mismatch("tables", s.Tables, translated.Tables)
+	}
+	if s.CodeBlocks != translated.CodeBlocks {
+		mismatch("code blocks", s.CodeBlocks, translated.CodeBlocks)
+	}
+	if !slices.Equal(s.Links, translated.Links) {
+		mismatch("links", s.Links, translated.Links)
+	}
+	if !slices.Equal(s.Images, translated.Images) {
+		mismatch("images", s.Images, translated.Images)
+	}
+	if s.Footnotes != translated.Footnotes {
+		mismatch("footnotes", s.Footnotes, translated.Footnotes)
+	}
+
+	return mismatches
+}
+
+// ValidateStructure는 Markdown 번역 결과물의 구조가 원본과 같은지 확인하며, 다르다면 ErrStructureMismatch를 반환합니다.
+// Markdown이 아닌 형식은 확인하지 않습니다.
+func ValidateStructure(format Format, source, translated Markdown) error {
+	if format != FormatMarkdown {
+		return nil
+	}
+
+	if mismatches := ParseStructure(source).Mismatches(ParseStructure(translated)); len(mismatches) > 0 {
+		return errors.Wrap(ErrStructureMismatch, strings.Join(mismatches, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,100 @@
+package file
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const structureSource = `---
+title: 안녕
+---
+# 제목
+
+본문에 [링크](https://example.com)와 ![이미지](/img/a.png "설명")가 있습니다.[^1]
+
+{{< figure src="/img/b.png" alt="그림" >}}
+
+## 목록
+
+- 하나
+- 둘
+  1. 셋
+
+| 이름 | 값 |
+| --- | --- |
+| a | 1 |
+
+` + "```go\nfmt.Println(\"안녕\")\n```" + `
+
+[^1]: 각주
+`
+
+func TestParseStructure(t *testing.T) {
+	got := ParseStructure(structureSource)
+
+	assert.Equal(t, Structure{
+		Headings:   []int{1, 2},
+		Lists:      []int{2, 1},
+		Tables:     []string{"2x2"},
+		CodeBlocks: 1,
+		Links:      []string{"https://example.com"},
+		Images:     []string{"/img/a.png", "/img/b.png"},
+		Footnotes:  1,
+	}, got)
+}
+
+func TestValidateStructure(t *testing.T) {
+	tests := []struct {
+		name       string
+		format     Format
+		translated Markdown
+		wantErr    bool
+	}{
+		{
+			name:       "구조가 같으면 문장이 달라도 통과",
+			format:     FormatMarkdown,
+			translated: "---\ntitle: Hello\n---\n# Title\n\nThere is ![image](/img/a.png \"caption\") and a [link](https://example.com) in the body.[^1]\n\n{{< figure src=\"/img/b.png\" alt=\"figure\" >}}\n\n## List\n\n- one\n- two\n  1. three\n\n| Name | Value |\n| --- | --- |\n| a | 1 |\n\n```go\nfmt.Println(\"안녕\")\n```\n\n[^1]: footnote\n",
+			wantErr:    false,
+		},
+		{
+			name:       "섹션이 빠진 경우",
+			format:     FormatMarkdown,
+			translated: "# Title\n\nThere is a [link](https://example.com) and ![image](/img/a.png).[^1]\n\n{{< figure src=\"/img/b.png\" alt=\"figure\" >}}\n\n- one\n- two\n  1. three\n\n| Name | Value |\n| --- | --- |\n| a | 1 |\n\n```go\nfmt.Println(\"안녕\")\n```\n\n[^1]: footnote\n",
+			wantErr:    true,
+		},
+		{
+			name:       "목록 항목이 합쳐진 경우",
+			format:     FormatMarkdown,
+			translated: "# Title\n\nThere is a [link](https://example.com) and ![image](/img/a.png).[^1]\n\n{{< figure src=\"/img/b.png\" alt=\"figure\" >}}\n\n## List\n\n- one, two\n  1. three\n\n| Name | Value |\n| --- | --- |\n| a | 1 |\n\n```go\nfmt.Println(\"안녕\")\n```\n\n[^1]: footnote\n",
+			wantErr:    true,
+		},
+		{
+			name:       "링크 대상이 바뀐 경우",
+			format:     FormatMarkdown,
+			translated: "# Title\n\nThere is a [link](https://example.org) and ![image](/img/a.png).[^1]\n\n{{< figure src=\"/img/b.png\" alt=\"figure\" >}}\n\n## List\n\n- one\n- two\n  1. three\n\n| Name | Value |\n| --- | --- |\n| a | 1 |\n\n```go\nfmt.Println(\"안녕\")\n```\n\n[^1]: footnote\n",
+			wantErr:    true,
+		},
+		{
+			name:       "이미지 경로가 바뀐 경우",
+			format:     FormatMarkdown,
+			translated: "---\ntitle: Hello\n---\n# Title\n\nThere is ![image](/img/a.png \"caption\") and a [link](https://example.com) in the body.[^1]\n\n{{< figure src=\"/img/en/b.png\" alt=\"figure\" >}}\n\n## List\n\n- one\n- two\n  1. three\n\n| Name | Value |\n| --- | --- |\n| a | 1 |\n\n```go\nfmt.Println(\"안녕\")\n```\n\n[^1]: footnote\n",
+			wantErr:    true,
+		},
+		{
+			name:       "Markdown이 아닌 형식은 확인하지 않음",
+			format:     FormatHTML,
+			translated: "<p>Hello</p>",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateStructure(tt.format, structureSource, tt.translated)
+			assert.Equalf(t, tt.wantErr, err != nil, "ValidateStructure() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrStructureMismatch)
+			}
+		})
+	}
+}
@@ -2,35 +2,122 @@ package file
 
 import (
 	"context"
+	"fmt"
 	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"sync"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/pathrule"
 	"github.com/pkg/errors"
 )
 
-type MarkdownFile struct {
+// ContentFile은 번역할 컨텐츠 파일 하나와 번역 대상 언어 하나의 쌍입니다.
+// Ext는 "."을 포함한 원본 파일의 확장자로, 컨텐츠 형식을 결정합니다.
+type ContentFile struct {
+	// SourcePath는 원본 파일의 ContentDir 기준 경로입니다.
+	SourcePath string
 	FileName   string
+	Ext        string
 	OriginDir  string
 	Language   config.LanguageCode
 	Content    Markdown
 	Translated Markdown
+	// QualityScore는 역번역으로 평가한 번역 품질(0~100)이며, 평가하지 않았다면 nil입니다.
+	QualityScore *int
+	// Slug는 번역 결과물 front matter에 기록할 URL slug이며, 비어있으면 기록하지 않습니다.
+	Slug string
+	// Previous는 이미 있는 번역 결과물의 내용이며, 처음 번역하는 경우 빈 문자열입니다.
+	Previous Markdown
+	// PreviousSource는 Previous를 번역한 원본의 내용이며, 보관된 원본이 없다면 빈 문자열입니다.
+	PreviousSource Markdown
+	// FrontMatter는 원본 파일의 front matter이며, target path rule의 {slug} 같은 변수에 사용됩니다.
+	FrontMatter map[string]any
 }
 
-type MarkdownFiles []MarkdownFile
+// Format은 확장자로부터 컨텐츠 형식을 판별하며, 알 수 없는 확장자는 Markdown으로 취급합니다.
+func (c ContentFile) Format() Format {
+	if format, ok := FormatFromExtension(c.Ext); ok {
+		return format
+	}
+
+	return FormatMarkdown
+}
+
+// PathVars는 target path rule을 렌더링하기 위한 변수를 반환합니다.
+func (c ContentFile) PathVars() pathrule.Vars {
+	return pathrule.Vars{
+		Origin:      c.OriginDir,
+		FileName:    c.FileName,
+		Language:    c.Language.String(),
+		Ext:         c.Ext,
+		FrontMatter: c.FrontMatter,
+	}
+}
+
+// TranslationKey는 Hugo가 언어별 번역 결과물을 서로 연결하는 translationKey를 반환합니다.
+// 원본 파일 front matter에 translationKey가 있으면 그 값을, 없으면 원본 언어 디렉터리를 제외한 원본 파일의 경로(ex. post/foo)를 사용하므로
+// 파일 이름과 content 디렉터리 구조에 관계없이 같은 원본의 번역 결과물은 항상 같은 값을 가집니다.
+func (c ContentFile) TranslationKey() string {
+	if translationKey, ok := c.FrontMatter["translationKey"]; ok && translationKey != nil {
+		return fmt.Sprint(translationKey)
+	}
+
+	return path.Join(filepath.ToSlash(c.OriginDir), c.FileName)
+}
+
+type ContentFiles []ContentFile
+
+// writerFrontMatterKeys는 번역 결과물을 저장할 때 writer가 front matter에 기록하는 key입니다.
+var writerFrontMatterKeys = []string{"translated", "source_hash", "translationKey", "translation_score", "slug"}
+
+// frontMatterValues는 번역 결과물의 front matter에 기록할 key, value 쌍입니다.
+func frontMatterValues(file ContentFile) []interface{} {
+	values := []interface{}{
+		"translated", true,
+		"source_hash", SourceHash(file.Content),
+		"translationKey", file.TranslationKey(),
+	}
+	if file.QualityScore != nil {
+		values = append(values, "translation_score", *file.QualityScore)
+	}
+	if file.Slug != "" {
+		values = append(values, "slug", file.Slug)
+	}
+
+	return values
+}
 
 type Writer interface {
-	Write(ctx context.Context, file MarkdownFile) error
+	Write(ctx context.Context, file ContentFile) error
+	Move(ctx context.Context, relocation Relocation) error
+	// Close는 Sink를 닫으며, 아카이브를 완성하거나 diff를 출력합니다.
+	Close() error
 }
 
 type WriterConfig struct {
 	ContentDir     string
 	TargetPathRule string
+	// Sink는 번역 결과물을 저장할 대상이며, nil이면 FileMode, DirMode로 ContentDir에 직접 저장합니다.
+	// Sink를 지정했다면 새로 만드는 번역 결과물과 디렉터리의 모드는 Sink를 만들 때 지정합니다.
+	Sink Sink
+	// FileMode, DirMode는 Sink가 nil일 때 새로 만드는 번역 결과물과 디렉터리의 모드이며, 0이면 DefaultFileMode, DefaultDirMode를 사용합니다.
+	FileMode os.FileMode
+	DirMode  os.FileMode
+	// WriteSourceTranslationKey가 true이면 front matter에 translationKey가 없는 원본 파일에도 번역 결과물과 같은 translationKey를 기록합니다.
+	WriteSourceTranslationKey bool
+	// Snapshots가 nil이 아니면 번역한 원본의 내용을 보관하여, 원본이 수정되었을 때 이전 원본과 번역 결과물을 함께 번역 요청에 사용할 수 있게 합니다.
+	Snapshots *SourceSnapshots
 }
 
 type writer struct {
 	cfg WriterConfig
+
+	mu sync.Mutex
+	// keyedSources는 translationKey를 기록한 원본 파일의 ContentDir 기준 경로
+	keyedSources map[string]bool
 }
 
 func NewWriter(cfg WriterConfig) Writer {
@@ -39,21 +126,122 @@ func NewWriter(cfg WriterConfig) Writer {
 	}
 }
 
-func (w writer) Write(ctx context.Context, file MarkdownFile) error {
-	targetPath := TargetFileContentPath(w.cfg.ContentDir, w.cfg.TargetPathRule, file.OriginDir, file.Language.String(), file.FileName)
-	slog.DebugContext(ctx, "output path for translated markdown", "path", targetPath)
+func (w *writer) sink() Sink {
+	if w.cfg.Sink == nil {
+		// dirSink는 상태가 없으므로 매번 만들어도 됨
+		return NewDirSink(w.cfg.ContentDir, w.cfg.FileMode, w.cfg.DirMode)
+	}
 
-	parent := filepath.Dir(targetPath)
+	return w.cfg.Sink
+}
 
-	if err := os.MkdirAll(parent, os.ModePerm); err != nil {
-		return errors.Wrap(err, "failed to create parent directory")
+// writesInPlace는 Sink가 ContentDir에 직접 저장하는지 여부입니다.
+// 아카이브, diff, 다른 디렉터리에 저장하는 경우에는 원본 파일을 함께 저장하면 안 되므로 false를 반환합니다.
+func (w *writer) writesInPlace() bool {
+	if w.cfg.Sink == nil {
+		return true
 	}
 
-	if err := WriteMarkdownWithFrontmatter(targetPath, []byte(file.Translated), os.ModePerm,
-		"translated", true,
-	); err != nil {
+	sink, ok := w.cfg.Sink.(*dirSink)
+
+	return ok && filepath.Clean(sink.root) == filepath.Clean(w.cfg.ContentDir)
+}
+
+func (w *writer) Write(ctx context.Context, file ContentFile) error {
+	targetPath, err := TargetFilePath(w.cfg.TargetPathRule, file)
+	if err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "output path for translated content", "path", targetPath)
+
+	if w.cfg.WriteSourceTranslationKey {
+		// 원본 파일이 바뀌므로 번역 결과물의 source_hash도 바뀐 원본 파일로 계산
+		if file.Content, err = w.writeSourceTranslationKey(ctx, file); err != nil {
+			return err
+		}
+	}
+
+	content, err := MarkdownWithFrontmatter([]byte(file.Translated), frontMatterValues(file)...)
+	if err != nil {
 		return err
 	}
 
+	if err = w.sink().WriteFile(targetPath, content); err != nil {
+		return err
+	}
+
+	if w.cfg.Snapshots != nil {
+		if err = w.cfg.Snapshots.Save(file.Content); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
+
+// writeSourceTranslationKey는 원본 파일 front matter에 translationKey가 없다면 기록하고, 기록한 원본 파일의 내용을 반환합니다.
+// 같은 원본 파일의 여러 언어를 번역하더라도 원본 파일은 한 번만 기록하며, front matter의 형식(YAML, TOML, JSON, Org)은 그대로 유지합니다.
+// Sink가 ContentDir에 직접 저장하지 않는다면 원본 파일이 출력에 섞이지 않도록 기록하지 않습니다.
+func (w *writer) writeSourceTranslationKey(ctx context.Context, file ContentFile) (Markdown, error) {
+	if _, ok := file.FrontMatter["translationKey"]; ok || file.SourcePath == "" {
+		return file.Content, nil
+	}
+
+	if !w.writesInPlace() {
+		slog.DebugContext(ctx, "skip writing translationKey to source outside content directory", "path", file.SourcePath)
+		return file.Content, nil
+	}
+
+	content, err := MarkdownWithFrontmatter([]byte(file.Content), "translationKey", file.TranslationKey())
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to add translationKey to %s", file.SourcePath)
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if !w.keyedSources[file.SourcePath] {
+		if err = w.sink().WriteFile(file.SourcePath, content); err != nil {
+			return "", errors.Wrapf(err, "failed to write translationKey to %s", file.SourcePath)
+		}
+
+		if w.keyedSources == nil {
+			w.keyedSources = make(map[string]bool)
+		}
+		w.keyedSources[file.SourcePath] = true
+		slog.DebugContext(ctx, "translationKey written to source", "path", file.SourcePath, "translationKey", file.TranslationKey())
+	}
+
+	return Markdown(content), nil
+}
+
+// Move는 번역 결과물을 relocation.To로 옮기고, 옮긴 원본 파일의 translationKey를 front matter에 반영합니다.
+// 옮길 번역 결과물은 항상 ContentDir에서 읽으며, Sink가 ContentDir이 아니면 ContentDir의 파일은 그대로 둡니다.
+func (w *writer) Move(ctx context.Context, relocation Relocation) error {
+	content, err := os.ReadFile(filepath.Join(w.cfg.ContentDir, relocation.From))
+	if err != nil {
+		return errors.Wrapf(err, "failed to read %s", relocation.From)
+	}
+
+	if relocation.TranslationKey != "" {
+		content, err = MarkdownWithFrontmatter(content, "translationKey", relocation.TranslationKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err = w.sink().WriteFile(relocation.To, content); err != nil {
+		return errors.Wrapf(err, "failed to move %s to %s", relocation.From, relocation.To)
+	}
+
+	if err = w.sink().Remove(relocation.From); err != nil {
+		return err
+	}
+	slog.DebugContext(ctx, "translated content moved", "from", relocation.From, "to", relocation.To)
+
+	return nil
+}
+
+func (w *writer) Close() error {
+	return w.sink().Close()
+}
@@ -3,9 +3,11 @@ package file
 import (
 	"context"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,14 +17,57 @@ func Test_writer_Write(t *testing.T) {
 	}
 	type args struct {
 		ctx  context.Context
-		file MarkdownFile
+		file ContentFile
 	}
 	tests := []struct {
 		name    string
 		fields  fields
 		args    args
+		want    string
 		wantErr bool
 	}{
+		{
+			name: "slug를 만든 경우 front matter에 기록",
+			fields: fields{
+				cfg: WriterConfig{
+					ContentDir:     "test_writer_content",
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				file: ContentFile{
+					FileName:   "test",
+					OriginDir:  "origin_dir",
+					Language:   config.LanguageCodeKorean,
+					Translated: "# Hello",
+					Slug:       "hello",
+				},
+			},
+			want:    "---\nslug: hello\nsource_hash: e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\ntranslated: true\ntranslationKey: origin_dir/test\n---\n# Hello",
+			wantErr: false,
+		},
+		{
+			name: "번역 품질을 평가한 경우 front matter에 점수를 기록",
+			fields: fields{
+				cfg: WriterConfig{
+					ContentDir:     "test_writer_content",
+					TargetPathRule: "{origin}/{fileName}.{language}.md",
+				},
+			},
+			args: args{
+				ctx: t.Context(),
+				file: ContentFile{
+					FileName:     "test",
+					OriginDir:    "origin_dir",
+					Language:     config.LanguageCodeKorean,
+					Translated:   "# Hello",
+					QualityScore: lo.ToPtr(87),
+				},
+			},
+			want:    "---\nsource_hash: e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\ntranslated: true\ntranslation_score: 87\ntranslationKey: origin_dir/test\n---\n# Hello",
+			wantErr: false,
+		},
 		{
 			name: "성공",
 			fields: fields{
@@ -33,13 +78,14 @@ func Test_writer_Write(t *testing.T) {
 			},
 			args: args{
 				ctx: t.Context(),
-				file: MarkdownFile{
+				file: ContentFile{
 					FileName:   "test",
 					OriginDir:  "origin_dir",
 					Language:   config.LanguageCodeKorean,
 					Translated: "# Hello",
 				},
 			},
+			want:    "---\nsource_hash: e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855\ntranslated: true\ntranslationKey: origin_dir/test\n---\n# Hello",
 			wantErr: false,
 		},
 	}
@@ -56,7 +102,7 @@ func Test_writer_Write(t *testing.T) {
 				t.Fatal(err)
 			}
 
-			assert.Equal(t, "---\ntranslated: true\n---\n# Hello", string(file))
+			assert.Equal(t, tt.want, string(file))
 		})
 	}
 }
@@ -91,3 +137,282 @@ func TestNewWriter(t *testing.T) {
 		})
 	}
 }
+
+func Test_writer_Move(t *testing.T) {
+	tests := []struct {
+		name       string
+		relocation Relocation
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "translationKey 반영",
+			relocation: Relocation{
+				From:           "post/foo.en.md",
+				To:             "renamed/bar.en.md",
+				TranslationKey: "foo",
+			},
+			want:    "---\ntranslated: true\ntranslationKey: foo\n---\n# Foo",
+			wantErr: false,
+		},
+		{
+			name: "translationKey가 없으면 이동만",
+			relocation: Relocation{
+				From: "post/foo.en.md",
+				To:   "post/bar.en.md",
+			},
+			want:    "---\ntranslated: true\n---\n# Foo",
+			wantErr: false,
+		},
+		{
+			name: "번역 결과물이 없음",
+			relocation: Relocation{
+				From: "post/none.en.md",
+				To:   "post/bar.en.md",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+			if err := os.MkdirAll(filepath.Join(contentDir, "post"), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(filepath.Join(contentDir, "post", "foo.en.md"), []byte("---\ntranslated: true\n---\n# Foo"), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			w := writer{
+				cfg: WriterConfig{ContentDir: contentDir},
+			}
+			err := w.Move(t.Context(), tt.relocation)
+			assert.Equalf(t, tt.wantErr, err != nil, "Move() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			file, err := os.ReadFile(filepath.Join(contentDir, tt.relocation.To))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, tt.want, string(file))
+			assert.NoFileExists(t, filepath.Join(contentDir, tt.relocation.From))
+		})
+	}
+}
+
+func Test_writer_Write_FileMode(t *testing.T) {
+	tests := []struct {
+		name     string
+		fileMode os.FileMode
+		config   os.FileMode
+		existing os.FileMode
+		wantMode os.FileMode
+	}{
+		{
+			name:     "기본 모드",
+			wantMode: DefaultFileMode,
+		},
+		{
+			name:     "Sink에 설정한 모드",
+			fileMode: 0o600,
+			wantMode: 0o600,
+		},
+		{
+			name:     "WriterConfig에 설정한 모드",
+			config:   0o640,
+			wantMode: 0o640,
+		},
+		{
+			name:     "기존 번역 결과물의 모드 유지",
+			existing: 0o640,
+			wantMode: 0o640,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := WriterConfig{
+				ContentDir:     t.TempDir(),
+				TargetPathRule: "{origin}/{fileName}.{language}.md",
+				FileMode:       tt.config,
+			}
+			if tt.fileMode != 0 {
+				cfg.Sink = NewDirSink(cfg.ContentDir, tt.fileMode, 0)
+			}
+			target := filepath.Join(cfg.ContentDir, "post", "test.ko.md")
+
+			if tt.existing != 0 {
+				if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.WriteFile(target, []byte("# Old"), tt.existing); err != nil {
+					t.Fatal(err)
+				}
+				if err := os.Chmod(target, tt.existing); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			w := writer{
+				cfg: cfg,
+			}
+			err := w.Write(t.Context(), ContentFile{
+				FileName:   "test",
+				OriginDir:  "post",
+				Language:   config.LanguageCodeKorean,
+				Translated: "# Hello",
+			})
+			assert.NoError(t, err)
+
+			info, err := os.Stat(target)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMode, info.Mode().Perm())
+		})
+	}
+}
+
+func Test_writer_Write_SourceTranslationKey(t *testing.T) {
+	tests := []struct {
+		name       string
+		source     string
+		wantSource string
+	}{
+		{
+			name:       "원본 파일에 translationKey 기록",
+			source:     "---\ntitle: 안녕\n---\n# 안녕",
+			wantSource: "---\ntitle: 안녕\ntranslationKey: post/foo\n---\n# 안녕",
+		},
+		{
+			name:       "이미 translationKey가 있으면 그대로",
+			source:     "---\ntitle: 안녕\ntranslationKey: hello\n---\n# 안녕",
+			wantSource: "---\ntitle: 안녕\ntranslationKey: hello\n---\n# 안녕",
+		},
+		{
+			name:       "TOML front matter에 translationKey 기록",
+			source:     "+++\ntitle = \"안녕\"\n+++\n# 안녕",
+			wantSource: "+++\ntitle = \"안녕\"\ntranslationKey = \"post/foo\"\n+++\n# 안녕",
+		},
+		{
+			name:       "JSON front matter에 translationKey 기록",
+			source:     "{\n  \"title\": \"안녕\"\n}\n# 안녕",
+			wantSource: "{\n  \"title\": \"안녕\",\n  \"translationKey\": \"post/foo\"\n}\n# 안녕",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+			source := filepath.Join(contentDir, "post", "foo.md")
+			if err := os.MkdirAll(filepath.Dir(source), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(source, []byte(tt.source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			var frontMatter map[string]any
+			if err := parseFrontMatter([]byte(tt.source), &frontMatter); err != nil {
+				t.Fatal(err)
+			}
+
+			w := writer{
+				cfg: WriterConfig{
+					ContentDir:                contentDir,
+					TargetPathRule:            "{origin}/{fileName}.{language}.md",
+					WriteSourceTranslationKey: true,
+				},
+			}
+			for _, lang := range []config.LanguageCode{config.LanguageCodeEnglish, config.LanguageCodeJapanese} {
+				err := w.Write(t.Context(), ContentFile{
+					SourcePath:  "post/foo.md",
+					FileName:    "foo",
+					OriginDir:   "post",
+					Language:    lang,
+					Content:     Markdown(tt.source),
+					Translated:  "# Hello",
+					FrontMatter: frontMatter,
+				})
+				assert.NoError(t, err)
+			}
+
+			got, err := os.ReadFile(source)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSource, string(got))
+
+			// 번역 결과물의 source_hash는 translationKey를 기록한 원본 파일의 hash
+			for _, lang := range []string{"en", "ja"} {
+				translated, err := os.ReadFile(filepath.Join(contentDir, "post", "foo."+lang+".md"))
+				assert.NoError(t, err)
+				assert.Contains(t, string(translated), "source_hash: "+SourceHash(Markdown(tt.wantSource)))
+			}
+		})
+	}
+}
+
+func Test_writer_Write_SourceTranslationKey_Sink(t *testing.T) {
+	const source = "---\ntitle: 안녕\n---\n# 안녕"
+
+	tests := []struct {
+		name       string
+		outputDir  bool
+		wantSource string
+	}{
+		{
+			name:       "content 디렉터리에 저장하면 원본 파일에 기록",
+			outputDir:  false,
+			wantSource: "---\ntitle: 안녕\ntranslationKey: post/foo\n---\n# 안녕",
+		},
+		{
+			name:       "다른 디렉터리에 저장하면 원본 파일은 그대로",
+			outputDir:  true,
+			wantSource: source,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+			sourcePath := filepath.Join(contentDir, "post", "foo.md")
+			if err := os.MkdirAll(filepath.Dir(sourcePath), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(sourcePath, []byte(source), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			outputDir := contentDir
+			if tt.outputDir {
+				outputDir = t.TempDir()
+			}
+
+			w := NewWriter(WriterConfig{
+				ContentDir:                contentDir,
+				TargetPathRule:            "{origin}/{fileName}.{language}.md",
+				Sink:                      NewDirSink(outputDir, 0, 0),
+				WriteSourceTranslationKey: true,
+			})
+			err := w.Write(t.Context(), ContentFile{
+				SourcePath:  "post/foo.md",
+				FileName:    "foo",
+				OriginDir:   "post",
+				Language:    config.LanguageCodeEnglish,
+				Content:     source,
+				Translated:  "# Hello",
+				FrontMatter: map[string]any{"title": "안녕"},
+			})
+			assert.NoError(t, err)
+
+			got, err := os.ReadFile(sourcePath)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantSource, string(got))
+
+			translated, err := os.ReadFile(filepath.Join(outputDir, "post", "foo.en.md"))
+			assert.NoError(t, err)
+			assert.Contains(t, string(translated), "translationKey: post/foo")
+			assert.Contains(t, string(translated), "source_hash: "+SourceHash(Markdown(tt.wantSource)))
+
+			if tt.outputDir {
+				assert.NoFileExists(t, filepath.Join(outputDir, "post", "foo.md"))
+			}
+		})
+	}
+}
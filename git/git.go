@@ -0,0 +1,189 @@
+// Package git은 로컬 git 바이너리를 실행하여 저장소의 정보를 조회합니다.
+package git
+
+import (
+	"bytes"
+	"context"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrNotRepository   = errors.New("not a git repository")
+	ErrNothingToCommit = errors.New("nothing to commit")
+)
+
+// Git은 dir을 작업 디렉터리로 git 커맨드를 실행합니다.
+// 반환하는 경로는 모두 dir 기준 상대 경로입니다.
+type Git struct {
+	dir string
+}
+
+func New(dir string) *Git {
+	return &Git{
+		dir: dir,
+	}
+}
+
+func (g *Git) run(ctx context.Context, args ...string) (string, error) {
+	var stdout, stderr bytes.Buffer
+
+	// 한글 등 ASCII가 아닌 경로가 escape되지 않도록 core.quotePath를 끔
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "core.quotePath=false"}, args...)...)
+	cmd.Dir = g.dir
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", errors.Wrapf(err, "git %s: %s", strings.Join(args, " "), strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+// IsRepository는 dir이 git 저장소 안에 있는지 확인합니다.
+func (g *Git) IsRepository(ctx context.Context) bool {
+	out, err := g.run(ctx, "rev-parse", "--is-inside-work-tree")
+
+	return err == nil && strings.TrimSpace(out) == "true"
+}
+
+// Renames는 커밋된 히스토리와 아직 커밋되지 않은 변경사항에서 이름이 바뀐 파일을 찾아
+// 이전 경로를 키로, 최종 경로를 값으로 하는 map을 반환합니다.
+// a -> b, b -> c처럼 여러 번 바뀐 경우 a, b 모두 c로 연결됩니다.
+func (g *Git) Renames(ctx context.Context) (map[string]string, error) {
+	if !g.IsRepository(ctx) {
+		return nil, ErrNotRepository
+	}
+
+	renames := make(map[string]string)
+
+	// 히스토리는 최신 커밋부터 출력되므로 오래된 순서로 적용
+	history, err := g.run(ctx, "log", "-M", "--relative", "--diff-filter=R", "--name-status", "--format=", "--reverse")
+	if err != nil {
+		return nil, err
+	}
+	applyRenames(renames, history)
+
+	// 스테이징 여부와 관계없이 HEAD 이후의 변경사항, 커밋이 없는 저장소는 무시
+	if _, err = g.run(ctx, "rev-parse", "--verify", "HEAD"); err == nil {
+		var worktree string
+		worktree, err = g.run(ctx, "diff", "-M", "--relative", "--diff-filter=R", "--name-status", "HEAD")
+		if err != nil {
+			return nil, err
+		}
+		applyRenames(renames, worktree)
+	}
+
+	// a -> b -> a처럼 원래 이름으로 돌아온 경우는 제외
+	for from, to := range renames {
+		if from == to {
+			delete(renames, from)
+		}
+	}
+
+	return renames, nil
+}
+
+// applyRenames는 "R100\told\tnew" 형식의 name-status 출력을 renames에 반영합니다.
+func applyRenames(renames map[string]string, nameStatus string) {
+	for _, line := range strings.Split(nameStatus, "\n") {
+		fields := strings.Split(line, "\t")
+		if len(fields) != 3 || !strings.HasPrefix(fields[0], "R") {
+			continue
+		}
+
+		from, to := fields[1], fields[2]
+		for old, current := range renames {
+			if current == from {
+				renames[old] = to
+			}
+		}
+		renames[from] = to
+	}
+}
+
+// lines는 git 출력을 줄 단위로 나누고 빈 줄을 제거합니다.
+func lines(out string) []string {
+	return slices.DeleteFunc(strings.Split(out, "\n"), func(line string) bool {
+		return strings.TrimSpace(line) == ""
+	})
+}
+
+// ShortHash는 ref가 가리키는 커밋의 짧은 해시를 반환합니다.
+func (g *Git) ShortHash(ctx context.Context, ref string) (string, error) {
+	out, err := g.run(ctx, "rev-parse", "--short", "--verify", ref+"^{commit}")
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(out), nil
+}
+
+// ChangedFiles는 base 이후 변경되었거나 새로 추가된 파일의 경로를 정렬하여 반환합니다.
+// 커밋되지 않은 변경사항과 ignore되지 않은 untracked 파일도 포함하며, 삭제된 파일도 포함될 수 있습니다.
+func (g *Git) ChangedFiles(ctx context.Context, base string) ([]string, error) {
+	if !g.IsRepository(ctx) {
+		return nil, ErrNotRepository
+	}
+
+	changed, err := g.run(ctx, "diff", "--name-only", "--relative", base, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	untracked, err := g.run(ctx, "ls-files", "--others", "--exclude-standard")
+	if err != nil {
+		return nil, err
+	}
+
+	files := slices.Concat(lines(changed), lines(untracked))
+	slices.Sort(files)
+
+	return slices.Compact(files), nil
+}
+
+// CreateBranch는 현재 작업 트리의 변경사항을 유지한 채 name 브랜치를 만들고 checkout합니다.
+func (g *Git) CreateBranch(ctx context.Context, name string) error {
+	_, err := g.run(ctx, "checkout", "-b", name)
+
+	return err
+}
+
+// Commit은 paths의 변경사항만 message로 커밋합니다.
+// 이미 스테이징된 다른 파일은 커밋에 포함하지 않으며, 변경사항이 없으면 ErrNothingToCommit을 반환합니다.
+func (g *Git) Commit(ctx context.Context, message string, paths ...string) error {
+	// 존재하지 않으면서 추적되지도 않는 경로는 pathspec 에러가 발생하므로 제외
+	tracked, err := g.run(ctx, slices.Concat([]string{"ls-files", "--"}, paths)...)
+	if err != nil {
+		return err
+	}
+	trackedPaths := lines(tracked)
+
+	paths = slices.DeleteFunc(slices.Clone(paths), func(path string) bool {
+		if slices.Contains(trackedPaths, path) {
+			return false
+		}
+
+		_, statErr := g.run(ctx, "ls-files", "--others", "--exclude-standard", "--error-unmatch", "--", path)
+		return statErr != nil
+	})
+	if len(paths) == 0 {
+		return ErrNothingToCommit
+	}
+
+	if _, err = g.run(ctx, slices.Concat([]string{"add", "-A", "--"}, paths)...); err != nil {
+		return err
+	}
+
+	if _, err = g.run(ctx, slices.Concat([]string{"diff", "--cached", "--quiet", "--"}, paths)...); err == nil {
+		return ErrNothingToCommit
+	}
+
+	_, err = g.run(ctx, slices.Concat([]string{"commit", "-q", "-m", message, "--"}, paths)...)
+
+	return err
+}
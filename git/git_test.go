@@ -0,0 +1,162 @@
+package git
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRepository는 임시 디렉터리에 git 저장소를 만들고 경로를 반환합니다.
+func newTestRepository(t *testing.T) string {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+	runGit(t, dir, "config", "user.email", "test@example.com")
+	runGit(t, dir, "config", "user.name", "test")
+
+	return dir
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %v: %s", args, out)
+	}
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	t.Helper()
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGit_Renames(t *testing.T) {
+	dir := newTestRepository(t)
+	contentDir := filepath.Join(dir, "content")
+
+	writeFile(t, filepath.Join(contentDir, "post", "foo.md"), "# 안녕하세요\n\n충분히 긴 본문입니다.\n")
+	writeFile(t, filepath.Join(contentDir, "post", "한글.md"), "# 한글 파일\n\n충분히 긴 본문입니다.\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+
+	// 커밋된 이름 변경
+	runGit(t, dir, "mv", "content/post/foo.md", "content/post/bar.md")
+	runGit(t, dir, "commit", "-q", "-m", "rename")
+
+	// 커밋되지 않은 연속된 이름 변경
+	runGit(t, dir, "mv", "content/post/bar.md", "content/post/baz.md")
+	runGit(t, dir, "mv", "content/post/한글.md", "content/post/korean.md")
+
+	got, err := New(contentDir).Renames(t.Context())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"post/foo.md": "post/baz.md",
+		"post/bar.md": "post/baz.md",
+		"post/한글.md":  "post/korean.md",
+	}, got)
+}
+
+func TestGit_Renames_NotRepository(t *testing.T) {
+	_, err := New(t.TempDir()).Renames(t.Context())
+	assert.ErrorIs(t, err, ErrNotRepository)
+}
+
+func TestGit_ChangedFiles(t *testing.T) {
+	dir := newTestRepository(t)
+	contentDir := filepath.Join(dir, "content")
+
+	writeFile(t, filepath.Join(dir, ".gitignore"), "*.tmp\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "# readme\n")
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), "# a\n")
+	writeFile(t, filepath.Join(contentDir, "post", "b.md"), "# b\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+	runGit(t, dir, "tag", "base")
+
+	// 커밋된 변경사항
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), "# a updated\n")
+	writeFile(t, filepath.Join(dir, "README.md"), "# readme updated\n")
+	runGit(t, dir, "commit", "-q", "-am", "update")
+
+	// 커밋되지 않은 변경사항과 untracked, ignored 파일
+	writeFile(t, filepath.Join(contentDir, "post", "b.md"), "# b updated\n")
+	writeFile(t, filepath.Join(contentDir, "post", "c.md"), "# c\n")
+	writeFile(t, filepath.Join(contentDir, "post", "d.tmp"), "ignored\n")
+
+	tests := []struct {
+		name    string
+		base    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "base 이후 변경된 파일",
+			base:    "base",
+			want:    []string{"post/a.md", "post/b.md", "post/c.md"},
+			wantErr: false,
+		},
+		{
+			name:    "HEAD 이후 변경된 파일",
+			base:    "HEAD",
+			want:    []string{"post/b.md", "post/c.md"},
+			wantErr: false,
+		},
+		{
+			name:    "존재하지 않는 ref",
+			base:    "unknown",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := New(contentDir).ChangedFiles(t.Context(), tt.base)
+			assert.Equalf(t, tt.wantErr, err != nil, "ChangedFiles() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGit_Commit(t *testing.T) {
+	dir := newTestRepository(t)
+	contentDir := filepath.Join(dir, "content")
+
+	writeFile(t, filepath.Join(contentDir, "post", "a.md"), "# a\n")
+	runGit(t, dir, "add", ".")
+	runGit(t, dir, "commit", "-q", "-m", "init")
+
+	g := New(contentDir)
+	assert.NoError(t, g.CreateBranch(t.Context(), "translations"))
+
+	// 스테이징된 다른 파일은 커밋에 포함되지 않아야 함
+	writeFile(t, filepath.Join(contentDir, "post", "staged.md"), "# staged\n")
+	runGit(t, dir, "add", "content/post/staged.md")
+
+	writeFile(t, filepath.Join(contentDir, "post", "a.en.md"), "# a\n")
+	assert.NoError(t, g.Commit(t.Context(), "Translate post/a.md into English", "post/a.en.md", "post/none.md"))
+	assert.ErrorIs(t, g.Commit(t.Context(), "Translate post/a.md into English", "post/a.en.md"), ErrNothingToCommit)
+
+	branch, err := g.run(t.Context(), "rev-parse", "--abbrev-ref", "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, "translations\n", branch)
+
+	files, err := g.run(t.Context(), "show", "--name-only", "--format=%s", "HEAD")
+	assert.NoError(t, err)
+	assert.Equal(t, "Translate post/a.md into English\n\ncontent/post/a.en.md\n", files)
+}
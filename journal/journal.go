@@ -0,0 +1,233 @@
+// Package journal은 번역 실행의 진행 상황을 content 디렉터리에 기록하여, 중단된 실행을 이어서 할 수 있도록 합니다.
+package journal
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/pkg/errors"
+)
+
+const (
+	// Dir은 content 디렉터리 안에서 hugo-ai-translator가 상태를 저장하는 디렉터리입니다.
+	// 숨김 디렉터리이므로 번역 대상과 감시 대상에서 제외됩니다.
+	Dir = ".hugo-ai-translator"
+	// FileName은 Dir 안의 journal 파일 이름입니다.
+	FileName = "journal.json"
+)
+
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+var ErrNotFound = errors.New("journal not found")
+
+// Entry는 원본 파일 하나를 하나의 언어로 번역하는 작업입니다.
+type Entry struct {
+	Source   string              `json:"source"`
+	Language config.LanguageCode `json:"language"`
+	Status   Status              `json:"status"`
+	Error    string              `json:"error,omitempty"`
+}
+
+// Options는 이어서 할 때 같은 번역 대상을 고르도록 journal에 함께 기록하는 실행 옵션입니다.
+type Options struct {
+	// UpdateStale은 원본이 수정된 언어도 다시 번역하는지 여부이며, --since로 실행한 경우에도 true입니다.
+	UpdateStale bool `json:"update_stale,omitempty"`
+	// Output은 번역 결과물을 저장한 디렉터리이며, content 디렉터리에 저장했다면 빈 문자열입니다.
+	Output string `json:"output,omitempty"`
+}
+
+// journalFile은 journal 파일의 형식입니다.
+type journalFile struct {
+	Options Options `json:"options"`
+	Entries []Entry `json:"entries"`
+}
+
+// Journal은 작업별 상태를 기록하며, 실행이 비정상적으로 종료되더라도 이어서 할 수 있도록 상태가 바뀔 때마다 파일에 저장합니다.
+type Journal struct {
+	path    string
+	options Options
+
+	mu      sync.Mutex
+	entries []Entry
+	// index는 원본 파일과 언어별 entries의 위치
+	index map[string]int
+}
+
+// Path는 contentDir의 journal 파일 경로를 반환합니다.
+func Path(contentDir string) string {
+	return filepath.Join(contentDir, Dir, FileName)
+}
+
+// New는 contentFiles를 모두 pending으로 기록한 journal을 만들며, Save를 호출하거나 상태가 바뀌기 전에는 파일을 쓰지 않습니다.
+func New(contentDir string, contentFiles file.ContentFiles, options Options) *Journal {
+	entries := make([]Entry, 0, len(contentFiles))
+	for _, contentFile := range contentFiles {
+		entries = append(entries, Entry{
+			Source:   contentFile.SourcePath,
+			Language: contentFile.Language,
+			Status:   StatusPending,
+		})
+	}
+
+	return newJournal(Path(contentDir), options, entries)
+}
+
+func newJournal(path string, options Options, entries []Entry) *Journal {
+	sortEntries(entries)
+
+	j := &Journal{
+		path:    path,
+		options: options,
+		entries: entries,
+		index:   make(map[string]int, len(entries)),
+	}
+	for i, entry := range entries {
+		j.index[entryKey(entry.Source, entry.Language)] = i
+	}
+
+	return j
+}
+
+// sortEntries는 journal 파일에 항상 같은 순서로 쓰이도록 원본 파일, 언어 순으로 정렬합니다.
+func sortEntries(entries []Entry) {
+	slices.SortFunc(entries, func(a, b Entry) int {
+		if c := strings.Compare(a.Source, b.Source); c != 0 {
+			return c
+		}
+
+		return strings.Compare(a.Language.String(), b.Language.String())
+	})
+}
+
+func entryKey(source string, language config.LanguageCode) string {
+	return source + "\x00" + language.String()
+}
+
+// Open은 contentDir의 journal을 읽으며, journal이 없다면 ErrNotFound를 반환합니다.
+func Open(contentDir string) (*Journal, error) {
+	data, err := os.ReadFile(Path(contentDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+		return nil, errors.Wrap(err, "failed to read journal")
+	}
+
+	var f journalFile
+	if err = json.Unmarshal(data, &f); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse journal %s", Path(contentDir))
+	}
+
+	return newJournal(Path(contentDir), f.Options, f.Entries), nil
+}
+
+// Options는 journal을 기록한 실행의 옵션을 반환합니다.
+func (j *Journal) Options() Options {
+	return j.options
+}
+
+// Entries는 기록된 작업을 원본 파일, 언어 순으로 반환합니다.
+func (j *Journal) Entries() []Entry {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	entries := slices.Clone(j.entries)
+	sortEntries(entries)
+
+	return entries
+}
+
+// Unfinished는 아직 끝나지 않았거나 실패한 작업을 반환합니다.
+func (j *Journal) Unfinished() []Entry {
+	return slices.DeleteFunc(j.Entries(), func(entry Entry) bool {
+		return entry.Status == StatusDone
+	})
+}
+
+// Done은 작업이 끝났음을 기록하고 journal 파일에 저장합니다.
+func (j *Journal) Done(source string, language config.LanguageCode) error {
+	return j.set(source, language, StatusDone, nil)
+}
+
+// Fail은 작업이 실패했음을 기록하고 journal 파일에 저장합니다.
+func (j *Journal) Fail(source string, language config.LanguageCode, cause error) error {
+	return j.set(source, language, StatusFailed, cause)
+}
+
+// Save는 --resume으로 이어서 할 수 있도록 기록한 작업을 journal 파일에 씁니다.
+// 쓰는 도중에 중단되더라도 이전 journal이 남도록 원자적으로 씁니다.
+func (j *Journal) Save() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	return j.save()
+}
+
+func (j *Journal) save() error {
+	// 기록하지 않았던 작업이 뒤에 추가되었을 수 있으므로 정렬한 복사본을 씀
+	entries := slices.Clone(j.entries)
+	sortEntries(entries)
+
+	data, err := json.MarshalIndent(journalFile{Options: j.options, Entries: entries}, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal journal")
+	}
+
+	if err = os.MkdirAll(filepath.Dir(j.path), file.DefaultDirMode); err != nil {
+		return errors.Wrap(err, "failed to create journal directory")
+	}
+
+	if err = file.WriteFileAtomic(j.path, append(data, '\n'), file.DefaultFileMode); err != nil {
+		return errors.Wrap(err, "failed to write journal")
+	}
+
+	return nil
+}
+
+// Remove는 모든 작업이 끝나 더 이상 이어서 할 작업이 없을 때 journal 파일을 지웁니다.
+func (j *Journal) Remove() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	if err := os.Remove(j.path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrap(err, "failed to remove journal")
+	}
+
+	return nil
+}
+
+func (j *Journal) set(source string, language config.LanguageCode, status Status, cause error) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	key := entryKey(source, language)
+	i, ok := j.index[key]
+	if !ok {
+		j.entries = append(j.entries, Entry{
+			Source:   source,
+			Language: language,
+		})
+		i = len(j.entries) - 1
+		j.index[key] = i
+	}
+
+	j.entries[i].Status = status
+	j.entries[i].Error = ""
+	if cause != nil {
+		j.entries[i].Error = cause.Error()
+	}
+
+	return j.save()
+}
@@ -0,0 +1,104 @@
+package journal
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJournal(t *testing.T) {
+	tests := []struct {
+		name           string
+		update         func(j *Journal)
+		wantUnfinished []Entry
+	}{
+		{
+			name:   "아무 작업도 끝나지 않음",
+			update: func(j *Journal) {},
+			wantUnfinished: []Entry{
+				{Source: "a.md", Language: config.LanguageCodeEnglish, Status: StatusPending},
+				{Source: "a.md", Language: config.LanguageCodeJapanese, Status: StatusPending},
+				{Source: "post/b.md", Language: config.LanguageCodeEnglish, Status: StatusPending},
+			},
+		},
+		{
+			name: "끝난 작업은 제외하고 실패한 작업은 포함",
+			update: func(j *Journal) {
+				assert.NoError(t, j.Done("a.md", config.LanguageCodeEnglish))
+				assert.NoError(t, j.Fail("post/b.md", config.LanguageCodeEnglish, errors.New("rate limited")))
+			},
+			wantUnfinished: []Entry{
+				{Source: "a.md", Language: config.LanguageCodeJapanese, Status: StatusPending},
+				{Source: "post/b.md", Language: config.LanguageCodeEnglish, Status: StatusFailed, Error: "rate limited"},
+			},
+		},
+		{
+			name: "실패한 작업을 다시 끝냄",
+			update: func(j *Journal) {
+				assert.NoError(t, j.Fail("a.md", config.LanguageCodeJapanese, errors.New("timeout")))
+				assert.NoError(t, j.Done("a.md", config.LanguageCodeJapanese))
+			},
+			wantUnfinished: []Entry{
+				{Source: "a.md", Language: config.LanguageCodeEnglish, Status: StatusPending},
+				{Source: "post/b.md", Language: config.LanguageCodeEnglish, Status: StatusPending},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contentDir := t.TempDir()
+
+			j := New(contentDir, file.ContentFiles{
+				{SourcePath: "post/b.md", Language: config.LanguageCodeEnglish},
+				{SourcePath: "a.md", Language: config.LanguageCodeJapanese},
+				{SourcePath: "a.md", Language: config.LanguageCodeEnglish},
+			}, Options{UpdateStale: true})
+			assert.NoError(t, j.Save())
+
+			tt.update(j)
+			assert.Equal(t, tt.wantUnfinished, j.Unfinished())
+
+			// 상태가 바뀔 때마다 저장하므로 다시 읽어도 같은 상태여야 함
+			reopened, err := Open(contentDir)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantUnfinished, reopened.Unfinished())
+			assert.Equal(t, Options{UpdateStale: true}, reopened.Options())
+		})
+	}
+}
+
+func TestOpen_NotFound(t *testing.T) {
+	_, err := Open(t.TempDir())
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestJournal_Remove(t *testing.T) {
+	contentDir := t.TempDir()
+
+	j := New(contentDir, file.ContentFiles{
+		{SourcePath: "a.md", Language: config.LanguageCodeEnglish},
+	}, Options{})
+	assert.NoError(t, j.Save())
+	assert.NoError(t, j.Remove())
+
+	_, err := Open(contentDir)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// 이미 지워진 journal을 다시 지워도 오류가 아님
+	assert.NoError(t, j.Remove())
+}
+
+func TestNew_NotSaved(t *testing.T) {
+	contentDir := t.TempDir()
+
+	// Save를 호출하거나 상태가 바뀌기 전에는 journal 파일을 쓰지 않음
+	New(contentDir, file.ContentFiles{
+		{SourcePath: "a.md", Language: config.LanguageCodeEnglish},
+	}, Options{})
+
+	_, err := Open(contentDir)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
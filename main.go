@@ -5,14 +5,42 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/cli"
 )
 
 func main() {
-	ctx := context.Background()
+	ctx, cancel := signalContext(context.Background())
+	defer cancel()
 
 	if err := cli.NewCommand().Run(ctx, os.Args); err != nil {
 		log.Fatal(fmt.Errorf("%+v", err))
 	}
 }
+
+// signalContext는 SIGINT, SIGTERM을 받으면 취소되는 context를 반환합니다.
+// 첫 신호에서는 새 번역을 시작하지 않고 진행 중인 저장이 끝나기를 기다리며, 다시 신호를 받으면 즉시 종료합니다.
+func signalContext(parent context.Context) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(parent)
+
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			signal.Stop(signals)
+			return
+		case sig := <-signals:
+			fmt.Fprintf(os.Stderr, "\nReceived %s, finishing in-flight writes. Send it again to exit immediately.\n", sig)
+			cancel()
+		}
+
+		<-signals
+		os.Exit(130)
+	}()
+
+	return ctx, cancel
+}
@@ -0,0 +1,176 @@
+package pathrule
+
+import (
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Matcher는 target path로부터 원본 파일의 정보를 역으로 찾아냅니다.
+type Matcher struct {
+	pattern *regexp.Regexp
+	// groups는 정규표현식 group 번호별 변수 이름입니다.
+	groups map[int]string
+}
+
+// Match는 target path에서 찾아낸 원본 파일의 정보입니다.
+// rule에 없는 변수는 빈 문자열이며, Origin은 최상위인 경우 "."입니다.
+type Match struct {
+	Vars
+}
+
+// Source는 원본 파일의 content_dir 기준 상대 경로를 반환합니다.
+// rule에 {ext}가 없다면 ext로 확장자를 지정해야 하며,
+// rule에 {fileName}이 없어 원본을 특정할 수 없으면 빈 문자열을 반환합니다.
+func (m Match) Source(ext string) string {
+	if m.FileName == "" {
+		return ""
+	}
+
+	if m.Ext != "" {
+		ext = m.Ext
+	}
+	if ext != "" && !strings.HasPrefix(ext, ".") {
+		ext = "." + ext
+	}
+
+	return path.Join(m.Origin, m.FileName+ext)
+}
+
+// Matcher는 languages 중 하나로 번역된 결과물의 경로와 매칭되는 Matcher를 반환합니다.
+func (r *Rule) Matcher(languages []string) *Matcher {
+	codes := slices.Clone(languages)
+	slices.Sort(codes)
+	for i, code := range codes {
+		codes[i] = regexp.QuoteMeta(code)
+	}
+
+	b := matcherBuilder{
+		languages: "(?:" + strings.Join(codes, "|") + ")",
+		groups:    make(map[int]string),
+	}
+	if len(codes) == 0 {
+		// 언어가 없으면 어떤 경로와도 매칭되지 않음
+		b.languages = "(?:$.)"
+	}
+
+	b.sb.WriteString("^")
+	b.build(r.nodes, true)
+	b.sb.WriteString("$")
+
+	return &Matcher{
+		pattern: regexp.MustCompile(b.sb.String()),
+		groups:  b.groups,
+	}
+}
+
+type matcherBuilder struct {
+	sb        strings.Builder
+	languages string
+	groups    map[int]string
+	count     int
+}
+
+func (b *matcherBuilder) group(name, pattern string) {
+	b.count++
+	b.groups[b.count] = name
+	b.sb.WriteString("(" + pattern + ")")
+}
+
+// build는 nodes를 정규표현식으로 변환합니다. atStart는 경로의 맨 앞인지 여부입니다.
+func (b *matcherBuilder) build(nodes []node, atStart bool) {
+	var optionalSlash bool
+	for i, n := range nodes {
+		switch n.kind {
+		case textNode:
+			text := n.text
+			if atStart {
+				// Render는 맨 앞의 "/", "./"를 제거하므로 동일하게 처리
+				text = strings.TrimLeft(strings.TrimPrefix(text, "./"), "/")
+			}
+			if optionalSlash {
+				// 바로 앞의 {origin}과 함께 생략 가능한 "/"는 이미 추가됨
+				text = strings.TrimPrefix(text, "/")
+			}
+			b.sb.WriteString(regexp.QuoteMeta(text))
+		case varNode:
+			switch n.name {
+			case VarOrigin:
+				// origin이 "."이면 Render 시 "./"가 제거되므로 뒤따르는 "/"까지 생략 가능
+				if i+1 < len(nodes) && nodes[i+1].kind == textNode && strings.HasPrefix(nodes[i+1].text, "/") {
+					b.sb.WriteString("(?:")
+					b.group(VarOrigin, ".+")
+					b.sb.WriteString("/)?")
+					optionalSlash = true
+					atStart = false
+					continue
+				}
+				b.group(VarOrigin, ".*")
+			case VarFileName:
+				b.group(VarFileName, "[^/]+")
+			case VarLanguage:
+				b.group(VarLanguage, b.languages)
+			case VarExt:
+				b.group(VarExt, "[^/.]+")
+			default:
+				b.group(n.name, "[^/]+")
+			}
+		case condNode:
+			b.sb.WriteString("(?:")
+			b.build(n.then, atStart)
+			b.sb.WriteString("|")
+			b.build(n.els, atStart)
+			b.sb.WriteString(")")
+		}
+		atStart = false
+		optionalSlash = false
+	}
+}
+
+// Match는 targetPath(content_dir 기준 상대 경로)가 번역 결과물의 경로인지 확인하고,
+// 원본 파일의 정보를 반환합니다.
+func (m *Matcher) Match(targetPath string) (Match, bool) {
+	targetPath = strings.TrimPrefix(path.Clean("/"+targetPath), "/")
+
+	groups := m.pattern.FindStringSubmatchIndex(targetPath)
+	if groups == nil {
+		return Match{}, false
+	}
+
+	match := Match{Vars: Vars{Origin: ".", FrontMatter: make(map[string]any)}}
+	for i := 1; i*2 < len(groups); i++ {
+		if groups[i*2] < 0 {
+			continue
+		}
+
+		value := targetPath[groups[i*2]:groups[i*2+1]]
+		switch name := m.groups[i]; name {
+		case VarOrigin:
+			if value != "" {
+				match.Origin = value
+			}
+		case VarFileName:
+			match.FileName = value
+		case VarLanguage:
+			match.Language = value
+		case VarExt:
+			match.Ext = "." + value
+		case VarSection:
+			// section은 origin의 일부이므로 origin이 없을 때만 사용
+			if match.Origin == "." {
+				match.Origin = value
+			}
+		default:
+			match.FrontMatter[name] = value
+		}
+	}
+
+	return match, true
+}
+
+// MatchString은 targetPath가 번역 결과물의 경로인지 확인합니다.
+func (m *Matcher) MatchString(targetPath string) bool {
+	_, ok := m.Match(targetPath)
+	return ok
+}
@@ -0,0 +1,298 @@
+// Package pathrule은 target_path_rule 템플릿을 파싱하고, 번역 결과물의 경로를 만들거나
+// 반대로 번역 결과물의 경로로부터 원본 파일의 정보를 찾아냅니다.
+//
+// 템플릿은 다음 문법을 지원합니다.
+//   - {origin}: content_dir 기준 원본 파일의 디렉터리 경로
+//   - {fileName}: 확장자를 제외한 원본 파일 이름 ({filename}도 허용)
+//   - {language}: 번역 대상 언어 코드
+//   - {ext}: "."을 제외한 원본 파일의 확장자
+//   - {section}: origin의 첫 번째 디렉터리 (Hugo의 section)
+//   - {slug}, {translationKey} 등: 원본 파일 front matter의 값
+//   - {if slug}...{else}...{end}: front matter 값 또는 변수가 비어있지 않은 경우에만 사용할 경로
+package pathrule
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrInvalidRule  = errors.New("invalid target path rule")
+	ErrMissingValue = errors.New("missing value for target path rule")
+)
+
+const (
+	VarOrigin   = "origin"
+	VarFileName = "fileName"
+	VarLanguage = "language"
+	VarExt      = "ext"
+	VarSection  = "section"
+)
+
+// reservedVars는 front matter가 아닌 예약어이며, 대소문자를 구분하지 않습니다.
+var reservedVars = map[string]string{
+	"origin":   VarOrigin,
+	"filename": VarFileName,
+	"language": VarLanguage,
+	"ext":      VarExt,
+	"section":  VarSection,
+}
+
+var namePattern = regexp.MustCompile(`^[A-Za-z_][\w.-]*$`)
+
+type nodeKind int
+
+const (
+	textNode nodeKind = iota
+	varNode
+	condNode
+)
+
+type node struct {
+	kind nodeKind
+	text string
+	name string
+	then []node
+	els  []node
+}
+
+// Rule은 파싱된 target path rule입니다.
+type Rule struct {
+	raw   string
+	nodes []node
+}
+
+// Parse는 target path rule을 파싱하고 유효성을 검사합니다.
+func Parse(rule string) (*Rule, error) {
+	if strings.TrimSpace(rule) == "" {
+		return nil, errors.Wrap(ErrInvalidRule, "rule is empty")
+	}
+
+	p := ruleParser{src: rule}
+	nodes, end, err := p.parse(0)
+	if err != nil {
+		return nil, err
+	}
+	if end != "" {
+		return nil, errors.Wrapf(ErrInvalidRule, "unexpected {%s} at %d in %q", end, p.pos, rule)
+	}
+
+	r := &Rule{raw: rule, nodes: nodes}
+	if !r.uses(VarLanguage) {
+		return nil, errors.Wrapf(ErrInvalidRule, "rule must contain {%s} to separate translations: %q", VarLanguage, rule)
+	}
+
+	return r, nil
+}
+
+// MustParse는 Parse와 같지만 에러가 발생하면 panic합니다.
+func MustParse(rule string) *Rule {
+	r, err := Parse(rule)
+	if err != nil {
+		panic(err)
+	}
+
+	return r
+}
+
+func (r *Rule) String() string {
+	return r.raw
+}
+
+// uses는 rule에서 name 변수를 한 번이라도 사용하는지 확인합니다.
+func (r *Rule) uses(name string) bool {
+	var walk func(nodes []node) bool
+	walk = func(nodes []node) bool {
+		for _, n := range nodes {
+			if n.kind == varNode && n.name == name {
+				return true
+			}
+			if n.kind == condNode && (n.name == name || walk(n.then) || walk(n.els)) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return walk(r.nodes)
+}
+
+type ruleParser struct {
+	src string
+	pos int
+}
+
+// parse는 depth 단계의 노드를 읽으며, {else} 또는 {end}를 만나면 해당 키워드와 함께 반환합니다.
+func (p *ruleParser) parse(depth int) ([]node, string, error) {
+	var nodes []node
+	for p.pos < len(p.src) {
+		open := strings.IndexAny(p.src[p.pos:], "{}")
+		if open < 0 {
+			nodes = append(nodes, node{kind: textNode, text: p.src[p.pos:]})
+			p.pos = len(p.src)
+			break
+		}
+		if open > 0 {
+			nodes = append(nodes, node{kind: textNode, text: p.src[p.pos : p.pos+open]})
+			p.pos += open
+		}
+		if p.src[p.pos] == '}' {
+			return nil, "", errors.Wrapf(ErrInvalidRule, "unexpected '}' at %d in %q", p.pos, p.src)
+		}
+
+		closing := strings.IndexByte(p.src[p.pos:], '}')
+		if closing < 0 {
+			return nil, "", errors.Wrapf(ErrInvalidRule, "unclosed '{' at %d in %q", p.pos, p.src)
+		}
+		tag := strings.TrimSpace(p.src[p.pos+1 : p.pos+closing])
+		start := p.pos
+		p.pos += closing + 1
+
+		switch fields := strings.Fields(tag); {
+		case tag == "else" || tag == "end":
+			if depth == 0 {
+				p.pos = start
+				return nil, "", errors.Wrapf(ErrInvalidRule, "{%s} without {if} at %d in %q", tag, start, p.src)
+			}
+
+			return nodes, tag, nil
+		case len(fields) == 2 && fields[0] == "if":
+			name, err := p.name(fields[1], start)
+			if err != nil {
+				return nil, "", err
+			}
+
+			cond := node{kind: condNode, name: name}
+			var end string
+			cond.then, end, err = p.parse(depth + 1)
+			if err != nil {
+				return nil, "", err
+			}
+			if end == "else" {
+				cond.els, end, err = p.parse(depth + 1)
+				if err != nil {
+					return nil, "", err
+				}
+			}
+			if end != "end" {
+				return nil, "", errors.Wrapf(ErrInvalidRule, "{if %s} at %d is not closed with {end} in %q", fields[1], start, p.src)
+			}
+
+			nodes = append(nodes, cond)
+		default:
+			name, err := p.name(tag, start)
+			if err != nil {
+				return nil, "", err
+			}
+
+			nodes = append(nodes, node{kind: varNode, name: name})
+		}
+	}
+
+	return nodes, "", nil
+}
+
+func (p *ruleParser) name(name string, pos int) (string, error) {
+	if !namePattern.MatchString(name) {
+		return "", errors.Wrapf(ErrInvalidRule, "invalid variable {%s} at %d in %q", name, pos, p.src)
+	}
+
+	if reserved, ok := reservedVars[strings.ToLower(name)]; ok {
+		return reserved, nil
+	}
+
+	return name, nil
+}
+
+// Vars는 target path를 만들기 위한 원본 파일의 정보입니다.
+type Vars struct {
+	// Origin은 content_dir 기준 원본 파일의 디렉터리 경로이며, 최상위는 "."입니다.
+	Origin   string
+	FileName string
+	Language string
+	// Ext는 "."을 포함하거나 포함하지 않은 확장자입니다.
+	Ext         string
+	FrontMatter map[string]any
+}
+
+// Section은 Origin의 첫 번째 디렉터리를 반환합니다.
+func (v Vars) Section() string {
+	origin := strings.Trim(path.Clean("/"+v.Origin), "/")
+	if origin == "" {
+		return ""
+	}
+
+	section, _, _ := strings.Cut(origin, "/")
+
+	return section
+}
+
+func (v Vars) lookup(name string) (string, bool) {
+	switch name {
+	case VarOrigin:
+		return v.Origin, true
+	case VarFileName:
+		return v.FileName, v.FileName != ""
+	case VarLanguage:
+		return v.Language, v.Language != ""
+	case VarExt:
+		ext := strings.TrimPrefix(v.Ext, ".")
+		return ext, ext != ""
+	case VarSection:
+		section := v.Section()
+		return section, section != ""
+	}
+
+	for key, value := range v.FrontMatter {
+		if !strings.EqualFold(key, name) || value == nil {
+			continue
+		}
+
+		str := fmt.Sprint(value)
+		return str, str != ""
+	}
+
+	return "", false
+}
+
+// Render는 vars로 rule의 변수를 치환한 content_dir 기준 상대 경로를 반환합니다.
+func (r *Rule) Render(vars Vars) (string, error) {
+	var sb strings.Builder
+	if err := render(&sb, r.nodes, vars); err != nil {
+		return "", err
+	}
+
+	return strings.TrimPrefix(path.Clean("/"+sb.String()), "/"), nil
+}
+
+func render(sb *strings.Builder, nodes []node, vars Vars) error {
+	for _, n := range nodes {
+		switch n.kind {
+		case textNode:
+			sb.WriteString(n.text)
+		case varNode:
+			value, ok := vars.lookup(n.name)
+			if !ok && n.name != VarOrigin {
+				return errors.Wrapf(ErrMissingValue, "{%s}", n.name)
+			}
+
+			sb.WriteString(value)
+		case condNode:
+			branch := n.els
+			if value, ok := vars.lookup(n.name); ok && value != "." {
+				branch = n.then
+			}
+
+			if err := render(sb, branch, vars); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,150 @@
+package pathrule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		wantErr bool
+	}{
+		{name: "기본 rule", rule: "{origin}/{fileName}.{language}.md"},
+		{name: "{filename}도 허용", rule: "{origin}/{filename}.{language}.{ext}"},
+		{name: "조건문", rule: "{language}/{section}/{if slug}{slug}{else}{fileName}{end}.md"},
+		{name: "빈 rule", rule: "", wantErr: true},
+		{name: "{language}가 없는 경우", rule: "{origin}/{fileName}.md", wantErr: true},
+		{name: "닫히지 않은 괄호", rule: "{origin/{fileName}.{language}.md", wantErr: true},
+		{name: "짝이 없는 닫는 괄호", rule: "{origin}}/{fileName}.{language}.md", wantErr: true},
+		{name: "{end}가 없는 조건문", rule: "{if slug}{slug}.{language}.md", wantErr: true},
+		{name: "{if}가 없는 {end}", rule: "{fileName}.{language}{end}.md", wantErr: true},
+		{name: "잘못된 변수 이름", rule: "{{origin}}/{fileName}.{language}.md", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse(tt.rule)
+			assert.Equalf(t, tt.wantErr, err != nil, "Parse(%q) error = %v, wantErr %v", tt.rule, err, tt.wantErr)
+		})
+	}
+}
+
+func TestRule_Render(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    string
+		vars    Vars
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "기본 rule",
+			rule: "{origin}/{fileName}.{language}.md",
+			vars: Vars{Origin: "post", FileName: "hello", Language: "en", Ext: ".md"},
+			want: "post/hello.en.md",
+		},
+		{
+			name: "최상위 디렉터리",
+			rule: "{origin}/{fileName}.{language}.{ext}",
+			vars: Vars{Origin: ".", FileName: "hello", Language: "ja", Ext: ".html"},
+			want: "hello.ja.html",
+		},
+		{
+			name: "section과 front matter",
+			rule: "{language}/{section}/{if slug}{slug}{else}{fileName}{end}.md",
+			vars: Vars{Origin: "post/2025", FileName: "hello", Language: "en", FrontMatter: map[string]any{"slug": "hi"}},
+			want: "en/post/hi.md",
+		},
+		{
+			name: "조건문의 else",
+			rule: "{language}/{section}/{if slug}{slug}{else}{fileName}{end}.md",
+			vars: Vars{Origin: "post/2025", FileName: "hello", Language: "en"},
+			want: "en/post/hello.md",
+		},
+		{
+			name:    "front matter 값이 없는 경우",
+			rule:    "{origin}/{slug}.{language}.md",
+			vars:    Vars{Origin: "post", FileName: "hello", Language: "en"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := MustParse(tt.rule).Render(tt.vars)
+			assert.Equalf(t, tt.wantErr, err != nil, "Render() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMatcher_Match(t *testing.T) {
+	languages := []string{"en", "ja"}
+
+	tests := []struct {
+		name       string
+		rule       string
+		targetPath string
+		want       Match
+		wantSource string
+		wantOk     bool
+	}{
+		{
+			name:       "기본 rule",
+			rule:       "{origin}/{fileName}.{language}.md",
+			targetPath: "post/hello.en.md",
+			want:       Match{Vars{Origin: "post", FileName: "hello", Language: "en", FrontMatter: map[string]any{}}},
+			wantSource: "post/hello.md",
+			wantOk:     true,
+		},
+		{
+			name:       "최상위 디렉터리와 확장자",
+			rule:       "{origin}/{fileName}.{language}.{ext}",
+			targetPath: "hello.ja.html",
+			want:       Match{Vars{Origin: ".", FileName: "hello", Language: "ja", Ext: ".html", FrontMatter: map[string]any{}}},
+			wantSource: "hello.html",
+			wantOk:     true,
+		},
+		{
+			name:       "언어별 디렉터리",
+			rule:       "{language}/{origin}/{fileName}.md",
+			targetPath: "ja/post/2025/hello.md",
+			want:       Match{Vars{Origin: "post/2025", FileName: "hello", Language: "ja", FrontMatter: map[string]any{}}},
+			wantSource: "post/2025/hello.md",
+			wantOk:     true,
+		},
+		{
+			name:       "조건문의 front matter",
+			rule:       "{origin}/{if slug}{slug}{else}{fileName}{end}.{language}.md",
+			targetPath: "post/hi.en.md",
+			want:       Match{Vars{Origin: "post", Language: "en", FrontMatter: map[string]any{"slug": "hi"}}},
+			wantSource: "",
+			wantOk:     true,
+		},
+		{
+			name:       "원본 파일",
+			rule:       "{origin}/{fileName}.{language}.md",
+			targetPath: "post/hello.md",
+			wantOk:     false,
+		},
+		{
+			name:       "설정되지 않은 언어",
+			rule:       "{origin}/{fileName}.{language}.md",
+			targetPath: "post/hello.de.md",
+			wantOk:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := MustParse(tt.rule).Matcher(languages).Match(tt.targetPath)
+			assert.Equal(t, tt.wantOk, ok)
+			if !tt.wantOk {
+				return
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantSource, got.Source(".md"))
+		})
+	}
+}
@@ -0,0 +1,74 @@
+package translator
+
+import (
+	"context"
+	_ "embed"
+	"log/slog"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/llm"
+	"github.com/openai/openai-go"
+	"github.com/pkg/errors"
+)
+
+var (
+	//go:embed judge_instruction.md
+	judgeInstructionMd string
+
+	//go:embed judge_prompt.md
+	judgePromptMd string
+)
+
+// Estimator는 번역 결과물의 품질을 평가합니다.
+type Estimator interface {
+	// Estimate는 source.Translated를 원본 언어로 다시 번역한 뒤, 원본과 의미가 얼마나 같은지 0~100으로 평가하여 source.QualityScore에 담습니다.
+	Estimate(ctx context.Context, source *file.ContentFile) error
+}
+
+func NewEstimator(client llm.OpenAIClient, cfg Config) Estimator {
+	return &translator{
+		client: client,
+		cfg:    &cfg,
+	}
+}
+
+func (t *translator) Estimate(ctx context.Context, source *file.ContentFile) error {
+	slog.DebugContext(ctx, "estimating translation quality", "language", source.Language, "originDir", source.OriginDir, "fileName", source.FileName)
+
+	backTranslation, err := t.translate(ctx, *source, source.Translated.String(), source.Language, t.cfg.SourceLanguage)
+	if err != nil {
+		return errors.Wrap(err, "failed to back-translate")
+	}
+
+	prompt, err := executeTemplate(judgePromptMd, struct {
+		Language        string
+		Format          string
+		Original        string
+		BackTranslation string
+	}{
+		Language:        t.cfg.SourceLanguage.Name().String(),
+		Format:          source.Format().Name(),
+		Original:        source.Content.String(),
+		BackTranslation: backTranslation,
+	})
+	if err != nil {
+		return err
+	}
+
+	var response QualityResponse
+	if err = t.complete(ctx, judgeInstructionMd, prompt, openai.ResponseFormatJSONSchemaJSONSchemaParam{
+		Name:        openai.F("quality"),
+		Description: openai.F("translation quality score"),
+		Schema:      openai.F(QualityScoreSchema()),
+		Strict:      openai.Bool(true),
+	}, &response); err != nil {
+		return errors.Wrap(err, "failed to score back-translation")
+	}
+
+	score := min(max(response.Score, 0), 100)
+	source.QualityScore = &score
+
+	slog.DebugContext(ctx, "translation quality estimated", "language", source.Language, "fileName", source.FileName, "score", score, "reason", response.Reason)
+
+	return nil
+}
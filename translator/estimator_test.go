@@ -0,0 +1,108 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/llm"
+	"github.com/YangTaeyoung/hugo-ai-translator/mocks"
+	"github.com/openai/openai-go"
+	"github.com/pkg/errors"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func completion(content string) *openai.ChatCompletion {
+	return &openai.ChatCompletion{
+		Choices: []openai.ChatCompletionChoice{
+			{
+				Message: openai.ChatCompletionMessage{
+					Content: content,
+				},
+			},
+		},
+	}
+}
+
+func Test_translator_Estimate(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockClient func() llm.OpenAIClient
+		want       *int
+		wantErr    bool
+	}{
+		{
+			name: "역번역 후 점수를 기록",
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.MatchedBy(func(params openai.ChatCompletionNewParams) bool {
+					return isSchema(params, "markdown")
+				})).Return(completion(`{"markdown":"안녕, 세상!"}`), nil).Once()
+				m.EXPECT().New(mock.Anything, mock.MatchedBy(func(params openai.ChatCompletionNewParams) bool {
+					return isSchema(params, "quality")
+				})).Return(completion(`{"score":92,"reason":"same meaning"}`), nil).Once()
+
+				return m
+			},
+			want:    lo.ToPtr(92),
+			wantErr: false,
+		},
+		{
+			name: "범위를 벗어난 점수는 0~100으로 제한",
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.MatchedBy(func(params openai.ChatCompletionNewParams) bool {
+					return isSchema(params, "markdown")
+				})).Return(completion(`{"markdown":"안녕, 세상!"}`), nil).Once()
+				m.EXPECT().New(mock.Anything, mock.MatchedBy(func(params openai.ChatCompletionNewParams) bool {
+					return isSchema(params, "quality")
+				})).Return(completion(`{"score":140,"reason":"perfect"}`), nil).Once()
+
+				return m
+			},
+			want:    lo.ToPtr(100),
+			wantErr: false,
+		},
+		{
+			name: "역번역에 실패하면 점수를 기록하지 않음",
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(nil, errors.New("rate limited")).Once()
+
+				return m
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := translator{
+				client: tt.mockClient(),
+				cfg: &Config{
+					SourceLanguage: config.LanguageCodeKorean,
+					Model:          openai.ChatModelGPT4oMini,
+				},
+			}
+			source := &file.ContentFile{
+				FileName:   "foo",
+				OriginDir:  "hello",
+				Content:    "안녕, 세계!",
+				Translated: "Hello, world!",
+				Language:   config.LanguageCodeEnglish,
+			}
+
+			err := tr.Estimate(t.Context(), source)
+			assert.Equalf(t, tt.wantErr, err != nil, "Estimate() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, source.QualityScore)
+		})
+	}
+}
+
+func isSchema(params openai.ChatCompletionNewParams, name string) bool {
+	format, ok := params.ResponseFormat.Value.(openai.ResponseFormatJSONSchemaParam)
+
+	return ok && format.JSONSchema.Value.Name.Value == name
+}
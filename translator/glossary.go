@@ -0,0 +1,63 @@
+package translator
+
+import (
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Glossary는 원본 언어의 용어별로 번역할 언어의 번역어를 정한 용어집입니다.
+// ex) {"쿠버네티스": {"en": "Kubernetes", "ja": "Kubernetes"}}
+type Glossary map[string]map[config.LanguageCode]string
+
+// GlossaryEntry는 번역할 내용에 나타난 용어와 번역어입니다.
+type GlossaryEntry struct {
+	Term        string
+	Translation string
+}
+
+// ReadGlossary는 YAML 형식의 용어집 파일을 읽으며, 지원하지 않는 언어가 있으면 에러를 반환합니다.
+func ReadGlossary(glossaryPath string) (Glossary, error) {
+	content, err := os.ReadFile(glossaryPath)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read glossary file")
+	}
+
+	var glossary Glossary
+	if err = yaml.Unmarshal(content, &glossary); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal glossary file %s", glossaryPath)
+	}
+
+	for term, translations := range glossary {
+		for lang := range translations {
+			if _, ok := config.LanguageCodeToLanguage[lang]; !ok {
+				return nil, errors.Errorf("invalid language of %q in glossary file %s: %q", term, glossaryPath, lang)
+			}
+		}
+	}
+
+	return glossary, nil
+}
+
+// Hits는 content에 나타난 용어 중 lang의 번역어가 있는 것을 용어 순으로 반환합니다.
+func (g Glossary) Hits(content string, lang config.LanguageCode) []GlossaryEntry {
+	var hits []GlossaryEntry
+	for term, translations := range g {
+		translation, ok := translations[lang]
+		if !ok || term == "" || !strings.Contains(content, term) {
+			continue
+		}
+
+		hits = append(hits, GlossaryEntry{Term: term, Translation: translation})
+	}
+
+	slices.SortFunc(hits, func(a, b GlossaryEntry) int {
+		return strings.Compare(a.Term, b.Term)
+	})
+
+	return hits
+}
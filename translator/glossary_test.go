@@ -0,0 +1,90 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadGlossary(t *testing.T) {
+	tests := []struct {
+		name         string
+		glossaryPath string
+		want         Glossary
+		wantErr      bool
+	}{
+		{
+			name:         "성공",
+			glossaryPath: "test_glossary/glossary.yaml",
+			want: Glossary{
+				"쿠버네티스": {config.LanguageCodeEnglish: "Kubernetes", config.LanguageCodeJapanese: "Kubernetes"},
+				"파드":    {config.LanguageCodeEnglish: "Pod"},
+			},
+			wantErr: false,
+		},
+		{
+			name:         "지원하지 않는 언어가 있는 경우",
+			glossaryPath: "test_glossary/invalid_glossary.yaml",
+			want:         nil,
+			wantErr:      true,
+		},
+		{
+			name:         "파일이 없는 경우",
+			glossaryPath: "test_glossary/none.yaml",
+			want:         nil,
+			wantErr:      true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ReadGlossary(tt.glossaryPath)
+			assert.Equalf(t, tt.wantErr, err != nil, "ReadGlossary() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGlossary_Hits(t *testing.T) {
+	glossary := Glossary{
+		"쿠버네티스": {config.LanguageCodeEnglish: "Kubernetes", config.LanguageCodeJapanese: "Kubernetes"},
+		"파드":    {config.LanguageCodeEnglish: "Pod"},
+		"노드":    {config.LanguageCodeEnglish: "Node"},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		lang    config.LanguageCode
+		want    []GlossaryEntry
+	}{
+		{
+			name:    "내용에 나타난 용어를 용어 순으로",
+			content: "쿠버네티스의 파드",
+			lang:    config.LanguageCodeEnglish,
+			want: []GlossaryEntry{
+				{Term: "쿠버네티스", Translation: "Kubernetes"},
+				{Term: "파드", Translation: "Pod"},
+			},
+		},
+		{
+			name:    "번역어가 없는 언어는 제외",
+			content: "쿠버네티스의 파드",
+			lang:    config.LanguageCodeJapanese,
+			want: []GlossaryEntry{
+				{Term: "쿠버네티스", Translation: "Kubernetes"},
+			},
+		},
+		{
+			name:    "나타난 용어가 없는 경우",
+			content: "안녕하세요",
+			lang:    config.LanguageCodeEnglish,
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, glossary.Hits(tt.content, tt.lang))
+		})
+	}
+}
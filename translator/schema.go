@@ -17,3 +17,16 @@ func GenerateSchema[T any]() interface{} {
 }
 
 var TranslateMarkdownSchema = GenerateSchema[TranslateResponse]
+
+type QualityResponse struct {
+	Score  int    `json:"score" jsonschema_description:"how well the back-translation preserves the meaning of the original, from 0 to 100"`
+	Reason string `json:"reason" jsonschema_description:"short reason for the score"`
+}
+
+var QualityScoreSchema = GenerateSchema[QualityResponse]
+
+type SlugResponse struct {
+	Slug string `json:"slug" jsonschema_description:"URL slug of the page in the target language"`
+}
+
+var SlugSchema = GenerateSchema[SlugResponse]
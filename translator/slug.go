@@ -0,0 +1,66 @@
+package translator
+
+import (
+	"context"
+	_ "embed"
+	"log/slog"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/llm"
+	"github.com/openai/openai-go"
+	"github.com/pkg/errors"
+)
+
+var (
+	//go:embed slug_instruction.md
+	slugInstructionMd string
+
+	//go:embed slug_prompt.md
+	slugPromptMd string
+)
+
+// Slugger는 번역 결과물의 URL slug를 만듭니다.
+type Slugger interface {
+	// Slug는 source의 제목을 source.Language로 옮긴 URL slug를 반환합니다.
+	// 반환된 slug는 URL에 사용할 수 있도록 file.Slugify를 거친 값입니다.
+	Slug(ctx context.Context, source file.ContentFile) (string, error)
+}
+
+func NewSlugger(client llm.OpenAIClient, cfg Config) Slugger {
+	return &translator{
+		client: client,
+		cfg:    &cfg,
+	}
+}
+
+func (t *translator) Slug(ctx context.Context, source file.ContentFile) (string, error) {
+	prompt, err := executeTemplate(slugPromptMd, struct {
+		Language string
+		Title    string
+	}{
+		Language: source.Language.Name().String(),
+		Title:    source.Title(),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	var response SlugResponse
+	if err = t.complete(ctx, slugInstructionMd, prompt, openai.ResponseFormatJSONSchemaJSONSchemaParam{
+		Name:        openai.F("slug"),
+		Description: openai.F("URL slug of the translated page"),
+		Schema:      openai.F(SlugSchema()),
+		Strict:      openai.Bool(true),
+	}, &response); err != nil {
+		return "", errors.Wrap(err, "failed to generate slug")
+	}
+
+	slug := file.Slugify(response.Slug)
+	if slug == "" {
+		return "", errors.Wrapf(ErrorEmptyResult, "slug %q has no URL-safe characters", response.Slug)
+	}
+
+	slog.DebugContext(ctx, "slug generated", "language", source.Language, "fileName", source.FileName, "slug", slug)
+
+	return slug, nil
+}
@@ -0,0 +1,68 @@
+package translator
+
+import (
+	"testing"
+
+	"github.com/YangTaeyoung/hugo-ai-translator/config"
+	"github.com/YangTaeyoung/hugo-ai-translator/file"
+	"github.com/YangTaeyoung/hugo-ai-translator/llm"
+	"github.com/YangTaeyoung/hugo-ai-translator/mocks"
+	"github.com/openai/openai-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func Test_translator_Slug(t *testing.T) {
+	tests := []struct {
+		name       string
+		mockClient func() llm.OpenAIClient
+		want       string
+		wantErr    bool
+	}{
+		{
+			name: "번역 결과물의 제목으로 slug를 생성",
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.MatchedBy(func(params openai.ChatCompletionNewParams) bool {
+					return isSchema(params, "slug")
+				})).Return(completion(`{"slug":"Hello World!"}`), nil).Once()
+
+				return m
+			},
+			want:    "hello-world",
+			wantErr: false,
+		},
+		{
+			name: "URL에 사용할 수 있는 문자가 없는 경우",
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"slug":"---"}`), nil).Once()
+
+				return m
+			},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tr := translator{
+				client: tt.mockClient(),
+				cfg: &Config{
+					SourceLanguage: config.LanguageCodeKorean,
+					Model:          openai.ChatModelGPT4oMini,
+				},
+			}
+
+			got, err := tr.Slug(t.Context(), file.ContentFile{
+				FileName:   "foo",
+				OriginDir:  "hello",
+				Content:    "---\ntitle: 안녕, 세계!\n---\n안녕, 세계!",
+				Translated: "---\ntitle: Hello, World!\n---\nHello, world!",
+				Language:   config.LanguageCodeEnglish,
+			})
+			assert.Equalf(t, tt.wantErr, err != nil, "Slug() error = %v, wantErr %v", err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
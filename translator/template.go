@@ -0,0 +1,99 @@
+package translator
+
+import (
+	"bytes"
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	defaultInstructionTemplate = template.Must(template.New("instruction").Parse(instructionMd))
+	defaultPromptTemplate      = template.Must(template.New("prompt").Parse(promptMd))
+)
+
+// PromptData는 instruction, prompt template에 전달하는 데이터입니다.
+type PromptData struct {
+	SourceLanguage string
+	TargetLanguage string
+	// Format은 컨텐츠 형식의 이름입니다. ex) Markdown
+	Format string
+	// Source는 번역하면 안 되는 마크업을 ⟦0⟧ 같은 token으로 감춘 번역할 내용입니다.
+	Source string
+	// Path는 원본 파일의 content_dir 기준 경로, Section은 Hugo의 section입니다.
+	Path    string
+	Section string
+	// FrontMatter는 원본 파일의 front matter입니다.
+	FrontMatter map[string]any
+	// Glossary는 번역할 내용에 나타난 용어집의 용어입니다.
+	Glossary []GlossaryEntry
+	// Previous는 이미 있는 번역 결과물이며, 처음 번역하는 경우 빈 문자열입니다.
+	Previous string
+	// PreviousSource는 Previous를 번역한 이전 원본이며, 원본이 수정되지 않았거나 이전 원본을 알 수 없는 경우 빈 문자열입니다.
+	PreviousSource string
+}
+
+// Templates는 번역 요청의 instruction과 prompt를 만드는 template입니다.
+type Templates struct {
+	Instruction *template.Template
+	Prompt      *template.Template
+}
+
+// ParseTemplates는 instruction, prompt template을 파싱하고, 잘못된 필드를 참조하지 않는지 예시 데이터로 실행해 봅니다.
+// 빈 문자열이면 기본 template을 사용합니다.
+func ParseTemplates(instruction, prompt string) (Templates, error) {
+	templates := Templates{
+		Instruction: defaultInstructionTemplate,
+		Prompt:      defaultPromptTemplate,
+	}
+
+	for _, t := range []struct {
+		name   string
+		text   string
+		target **template.Template
+	}{
+		{name: "instruction", text: instruction, target: &templates.Instruction},
+		{name: "prompt", text: prompt, target: &templates.Prompt},
+	} {
+		if t.text == "" {
+			continue
+		}
+
+		tmpl, err := template.New(t.name).Parse(t.text)
+		if err != nil {
+			return Templates{}, errors.Wrapf(err, "invalid %s template", t.name)
+		}
+
+		if _, err = render(tmpl, examplePromptData); err != nil {
+			return Templates{}, errors.Wrapf(err, "invalid %s template", t.name)
+		}
+
+		*t.target = tmpl
+	}
+
+	return templates, nil
+}
+
+// examplePromptData는 template을 검증하기 위해 모든 필드를 채운 예시 데이터입니다.
+var examplePromptData = PromptData{
+	SourceLanguage: "Korean",
+	TargetLanguage: "English",
+	Format:         "Markdown",
+	Source:         "# 안녕하세요",
+	Path:           "post/hello.md",
+	Section:        "post",
+	FrontMatter:    map[string]any{"title": "안녕하세요"},
+	Glossary:       []GlossaryEntry{{Term: "안녕하세요", Translation: "Hello"}},
+	Previous:       "# Hello",
+	PreviousSource: "# 안녕",
+}
+
+// render는 tmpl을 data로 실행한 결과를 반환합니다.
+func render(tmpl *template.Template, data any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
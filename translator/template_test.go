@@ -0,0 +1,82 @@
+package translator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseTemplates(t *testing.T) {
+	tests := []struct {
+		name            string
+		instruction     string
+		prompt          string
+		wantInstruction string
+		wantPrompt      string
+		wantErr         bool
+	}{
+		{
+			name:            "비어있으면 기본 template",
+			wantInstruction: instructionMd,
+			wantErr:         false,
+		},
+		{
+			name:            "사용자 template",
+			instruction:     "Translate {{ .Path }} in the {{ .Section }} section.",
+			prompt:          "{{ .FrontMatter.title }} {{ range .Glossary }}{{ .Term }}={{ .Translation }}{{ end }} {{ .Previous }}",
+			wantInstruction: "Translate post/hello.md in the post section.",
+			wantPrompt:      "안녕하세요 안녕하세요=Hello # Hello",
+			wantErr:         false,
+		},
+		{
+			name:    "문법이 잘못된 경우",
+			prompt:  "{{ .Source ",
+			wantErr: true,
+		},
+		{
+			name:        "없는 필드를 참조하는 경우",
+			instruction: "{{ .Language }}",
+			wantErr:     true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseTemplates(tt.instruction, tt.prompt)
+			assert.Equalf(t, tt.wantErr, err != nil, "ParseTemplates() error = %v, wantErr %v", err, tt.wantErr)
+			if tt.wantErr {
+				return
+			}
+
+			instruction, err := render(got.Instruction, examplePromptData)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantInstruction, instruction)
+
+			if tt.wantPrompt != "" {
+				prompt, err := render(got.Prompt, examplePromptData)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.wantPrompt, prompt)
+			}
+		})
+	}
+}
+
+func Test_defaultPromptTemplate_Glossary(t *testing.T) {
+	prompt, err := render(defaultPromptTemplate, examplePromptData)
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "- glossary\n  - translate the following terms of the source language as given.\n  - 안녕하세요 -> Hello\n\n## SourceLanguage")
+}
+
+func Test_defaultPromptTemplate_Previous(t *testing.T) {
+	prompt, err := render(defaultPromptTemplate, examplePromptData)
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "## PreviousSource\n\"\"\"\n# 안녕\n\"\"\"\n\n## PreviousTranslation\n\"\"\"\n# Hello\n\"\"\"")
+
+	// 이전 원본이 없으면 원본을 처음 번역할 때와 같은 prompt
+	data := examplePromptData
+	data.PreviousSource = ""
+	prompt, err = render(defaultPromptTemplate, data)
+	assert.NoError(t, err)
+	assert.NotContains(t, prompt, "PreviousTranslation")
+	assert.True(t, strings.HasSuffix(prompt, "## Source\n\"\"\"\n# 안녕하세요\n\"\"\""))
+}
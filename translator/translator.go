@@ -1,12 +1,12 @@
 package translator
 
 import (
-	"bytes"
 	"context"
 	_ "embed"
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 	"text/template"
 
 	"github.com/YangTaeyoung/hugo-ai-translator/config"
@@ -26,6 +26,9 @@ var (
 
 var MaxWorkers = 4
 
+// MaxStructureRetries는 번역 결과물의 구조가 원본과 다르거나 placeholder를 복원하지 못했을 때 다시 번역을 요청하는 최대 횟수입니다.
+var MaxStructureRetries = 2
+
 var (
 	ErrorEmptyResult = errors.New("empty result")
 )
@@ -34,10 +37,43 @@ type Config struct {
 	SourceLanguage  config.LanguageCode
 	TargetLanguages config.LanguageCodes
 	Model           openai.ChatModel
+	// SiteInstruction은 모든 언어의 instruction에 덧붙일 사이트 전체의 지침입니다.
+	SiteInstruction string
+	// LanguageInstructions는 번역할 언어별로 SiteInstruction 뒤에 덧붙일 지침입니다.
+	LanguageInstructions map[config.LanguageCode]string
+	// Templates는 instruction, prompt template이며, 비어있으면 기본 template을 사용합니다.
+	Templates Templates
+	// Glossary는 원본 언어에서 번역할 때 prompt에 전달할 용어집입니다.
+	Glossary Glossary
+}
+
+// templates는 설정된 template을, 설정되지 않았다면 기본 template을 반환합니다.
+func (c *Config) templates() Templates {
+	templates := c.Templates
+	if templates.Instruction == nil {
+		templates.Instruction = defaultInstructionTemplate
+	}
+	if templates.Prompt == nil {
+		templates.Prompt = defaultPromptTemplate
+	}
+
+	return templates
+}
+
+// instruction은 to 언어로 번역할 때의 developer message로, base instruction 뒤에 사이트 전체, 언어별 지침 순으로 덧붙입니다.
+func (c *Config) instruction(base string, to config.LanguageCode) string {
+	instructions := []string{base}
+	for _, instruction := range []string{c.SiteInstruction, c.LanguageInstructions[to]} {
+		if instruction = strings.TrimSpace(instruction); instruction != "" {
+			instructions = append(instructions, instruction)
+		}
+	}
+
+	return strings.Join(instructions, "\n\n")
 }
 
 type Translator interface {
-	Translate(ctx context.Context, source *file.MarkdownFile) error
+	Translate(ctx context.Context, source *file.ContentFile) error
 }
 
 type translator struct {
@@ -52,49 +88,159 @@ func New(client llm.OpenAIClient, cfg Config) Translator {
 	}
 }
 
-func (t *translator) Translate(ctx context.Context, source *file.MarkdownFile) error {
-	var (
-		tmpl *template.Template
-		res  *openai.ChatCompletion
-		err  error
-	)
-	slog.DebugContext(ctx, "translating markdown file", "language", source.Language, "originDir", source.OriginDir, "fileName", source.FileName)
+func (t *translator) Translate(ctx context.Context, source *file.ContentFile) error {
+	slog.DebugContext(ctx, "translating content file", "language", source.Language, "originDir", source.OriginDir, "fileName", source.FileName)
 
-	tmpl, err = template.New("prompt").Parse(promptMd)
+	translated, ok, err := t.translateChanged(ctx, *source)
 	if err != nil {
 		return err
 	}
+	if ok {
+		if err = file.ValidateStructure(source.Format(), source.Content, file.Markdown(translated)); err == nil {
+			source.Translated = file.Markdown(translated)
 
-	var buf bytes.Buffer
+			slog.DebugContext(ctx, "translated changed paragraphs of content file", "language", source.Language, "fileName", source.FileName)
 
-	if err = tmpl.Execute(&buf, struct {
-		SourceLanguage string
-		TargetLanguage string
-		Source         string
-	}{
-		SourceLanguage: t.cfg.SourceLanguage.Name().String(),
-		TargetLanguage: source.Language.Name().String(),
-		Source:         source.Content.String(),
-	}); err != nil {
-		return err
+			return nil
+		}
+		slog.WarnContext(ctx, "translated structure does not match source, translating whole content", "language", source.Language, "fileName", source.FileName, "error", err)
+	}
+
+	for attempt := 0; ; attempt++ {
+		translated, err = t.translate(ctx, *source, source.Content.String(), t.cfg.SourceLanguage, source.Language)
+		if err != nil && !file.IsPlaceholderError(err) {
+			return err
+		}
+
+		// placeholder를 잘못 옮겼거나, 섹션이 빠지거나 목록이 합쳐지는 등 구조가 바뀐 번역은 다시 요청
+		if err == nil {
+			err = file.ValidateStructure(source.Format(), source.Content, file.Markdown(translated))
+		}
+		if err == nil {
+			break
+		}
+		if attempt >= MaxStructureRetries {
+			return err
+		}
+		slog.WarnContext(ctx, "translated structure does not match source, retrying", "language", source.Language, "fileName", source.FileName, "attempt", attempt+1, "error", err)
 	}
 
-	prompt := buf.String()
+	source.Translated = file.Markdown(translated)
+
+	slog.DebugContext(ctx, "translated content file", "language", source.Language, "fileName", source.FileName)
+
+	return nil
+}
+
+// translateChanged는 이전 원본과 이전 번역 결과물의 문단을 짝지을 수 있다면, 바뀐 문단만 번역하고 바뀌지 않은 문단은 이전 번역을 그대로 사용한 번역 결과물을 반환합니다.
+// 연속해서 바뀐 문단은 한 번에 번역하며, 짝지을 수 없다면 false를 반환합니다.
+func (t *translator) translateChanged(ctx context.Context, source file.ContentFile) (string, bool, error) {
+	if source.Format() != file.FormatMarkdown || source.Previous == "" || source.PreviousSource == "" {
+		return "", false, nil
+	}
 
-	schemaParam := openai.ResponseFormatJSONSchemaJSONSchemaParam{
+	alignment, ok := file.Align(source.PreviousSource, source.Previous)
+	if !ok {
+		return "", false, nil
+	}
+
+	// 바뀐 문단을 번역할 때는 이전 원본과 번역 결과물 전체를 prompt에 넣지 않음
+	changed := source
+	changed.Previous, changed.PreviousSource = "", ""
+
+	var (
+		plan       = alignment.Plan(source.Content)
+		paragraphs = make([]string, 0, len(plan))
+	)
+	for i := 0; i < len(plan); {
+		if plan[i].Aligned {
+			paragraphs = append(paragraphs, plan[i].Translated)
+			i++
+			continue
+		}
+
+		var run []string
+		for ; i < len(plan) && !plan[i].Aligned; i++ {
+			run = append(run, plan[i].Source)
+		}
+
+		translated, err := t.translate(ctx, changed, file.JoinParagraphs(run, false).String(), t.cfg.SourceLanguage, source.Language)
+		if file.IsPlaceholderError(err) {
+			// 전체 번역에서 다시 요청
+			slog.WarnContext(ctx, "failed to restore markup in changed paragraphs, translating whole content", "language", source.Language, "fileName", source.FileName, "error", err)
+			return "", false, nil
+		}
+		if err != nil {
+			return "", false, err
+		}
+		paragraphs = append(paragraphs, strings.Trim(translated, "\n"))
+	}
+
+	return file.JoinParagraphs(paragraphs, strings.HasSuffix(source.Content.String(), "\n")).String(), true, nil
+}
+
+// translate는 source의 원본 또는 번역 결과물인 content를 from 언어에서 to 언어로 번역합니다.
+func (t *translator) translate(ctx context.Context, source file.ContentFile, content string, from, to config.LanguageCode) (string, error) {
+	format := source.Format()
+
+	// 번역하면 안 되는 마크업은 placeholder로 감추고, 번역 후 원래대로 복원
+	segments := file.NewSegmenter(format).Segment(content)
+
+	data := PromptData{
+		SourceLanguage: from.Name().String(),
+		TargetLanguage: to.Name().String(),
+		Format:         format.Name(),
+		Source:         segments.Mask(),
+		Path:           source.SourcePath,
+		Section:        source.PathVars().Section(),
+		FrontMatter:    source.FrontMatter,
+	}
+	// 용어집과 이전 번역 결과물은 원본 언어에서 번역할 때만 사용
+	if from == t.cfg.SourceLanguage {
+		data.Glossary = t.cfg.Glossary.Hits(content, to)
+		data.Previous = source.Previous.String()
+		data.PreviousSource = source.PreviousSource.String()
+	}
+
+	templates := t.cfg.templates()
+
+	instruction, err := render(templates.Instruction, data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render instruction template")
+	}
+
+	prompt, err := render(templates.Prompt, data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to render prompt template")
+	}
+
+	var response TranslateResponse
+	if err = t.complete(ctx, t.cfg.instruction(instruction, to), prompt, openai.ResponseFormatJSONSchemaJSONSchemaParam{
 		Name:        openai.F("markdown"),
 		Description: openai.F("translated markdown"),
 		Schema:      openai.F(TranslateMarkdownSchema()),
 		Strict:      openai.Bool(true),
+	}, &response); err != nil {
+		return "", errors.Wrap(err, "failed to translate markdown")
+	}
+
+	translated, err := segments.Unmask(response.Markdown)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to restore markup in translated content")
 	}
 
-	res, err = t.client.New(ctx, openai.ChatCompletionNewParams{
+	return translated, nil
+}
+
+// complete는 instruction과 prompt로 schema 형식의 응답을 요청하고, 응답을 response에 담습니다.
+func (t *translator) complete(ctx context.Context, instruction, prompt string, schema openai.ResponseFormatJSONSchemaJSONSchemaParam, response any) error {
+	res, err := t.client.New(ctx, openai.ChatCompletionNewParams{
 		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
 			openai.ChatCompletionDeveloperMessageParam{
 				Role: openai.F(openai.ChatCompletionDeveloperMessageParamRoleDeveloper),
 				Content: openai.F([]openai.ChatCompletionContentPartTextParam{
 					{
-						Text: openai.F(instructionMd),
+						Text: openai.F(instruction),
 						Type: openai.F(openai.ChatCompletionContentPartTextTypeText),
 					},
 				}),
@@ -104,13 +250,12 @@ func (t *translator) Translate(ctx context.Context, source *file.MarkdownFile) e
 		ResponseFormat: openai.F[openai.ChatCompletionNewParamsResponseFormatUnion](
 			openai.ResponseFormatJSONSchemaParam{
 				Type:       openai.F(openai.ResponseFormatJSONSchemaTypeJSONSchema),
-				JSONSchema: openai.F(schemaParam),
+				JSONSchema: openai.F(schema),
 			}),
 		Model: openai.F(t.cfg.Model),
 	})
-
 	if err != nil {
-		return errors.Wrap(err, "failed to translate markdown")
+		return err
 	}
 
 	if len(res.Choices) == 0 {
@@ -121,15 +266,19 @@ func (t *translator) Translate(ctx context.Context, source *file.MarkdownFile) e
 		return ErrorEmptyResult
 	}
 
-	var response TranslateResponse
-	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), &response); err != nil {
+	if err = json.Unmarshal([]byte(res.Choices[0].Message.Content), response); err != nil {
 		fmt.Println(res.Choices[0].Message.Content)
-		return errors.Wrap(err, "failed to unmarshal translated markdown response")
+		return errors.Wrap(err, "failed to unmarshal response")
 	}
 
-	source.Translated = file.Markdown(response.Markdown)
+	return nil
+}
 
-	slog.DebugContext(ctx, "translated markdown file", "language", source.Language, "fileName", source.FileName)
+func executeTemplate(text string, data any) (string, error) {
+	tmpl, err := template.New("prompt").Parse(text)
+	if err != nil {
+		return "", err
+	}
 
-	return nil
+	return render(tmpl, data)
 }
@@ -45,7 +45,7 @@ func Test_Integration_Translate(t *testing.T) {
 
 	type args struct {
 		ctx    context.Context
-		source *file.MarkdownFile
+		source *file.ContentFile
 	}
 	tests := []struct {
 		name    string
@@ -65,7 +65,7 @@ func Test_Integration_Translate(t *testing.T) {
 			},
 			args: args{
 				ctx: ctx,
-				source: &file.MarkdownFile{
+				source: &file.ContentFile{
 					Content:   file.Markdown(testPostingMd),
 					OriginDir: "path/to",
 					FileName:  "file",
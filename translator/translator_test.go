@@ -19,9 +19,10 @@ func Test_translator_Translate(t *testing.T) {
 purpose is to correctly convert the source language to the target language.
 
 The content that needs to be translated is as follows.
-- title field in markdown front matter
+- title field in front matter
   - do not ":" in the title field because it is used as a delimiter.
 - Markdown content
+  - tokens like ⟦0⟧ stand for markup that must not be translated. keep every token exactly once and in the right place.
 
 ## SourceLanguage
 Korean
@@ -39,7 +40,7 @@ English
 	}
 	type args struct {
 		ctx    context.Context
-		source *file.MarkdownFile
+		source *file.ContentFile
 	}
 	tests := []struct {
 		name       string
@@ -100,7 +101,7 @@ English
 			},
 			args: args{
 				ctx: t.Context(),
-				source: &file.MarkdownFile{
+				source: &file.ContentFile{
 					FileName:  "foo",
 					OriginDir: "hello",
 					Content:   "안녕, 세계!",
@@ -155,7 +156,7 @@ English
 			},
 			args: args{
 				ctx: t.Context(),
-				source: &file.MarkdownFile{
+				source: &file.ContentFile{
 					OriginDir: "hello",
 					FileName:  "foo",
 					Content:   "안녕, 세계!",
@@ -216,7 +217,96 @@ English
 			},
 			args: args{
 				ctx: t.Context(),
-				source: &file.MarkdownFile{
+				source: &file.ContentFile{
+					FileName:  "foo",
+					OriginDir: "hello",
+					Content:   "안녕, 세계!",
+					Language:  config.LanguageCodeEnglish,
+				},
+			},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name: "구조가 다른 번역은 다시 요청",
+			fields: fields{
+				cfg: &Config{
+					SourceLanguage: config.LanguageCodeKorean,
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+					},
+					Model: openai.ChatModelGPT4oMini,
+				},
+			},
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"markdown":"# Hello, world!"}`), nil).Once()
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"markdown":"Hello, world!"}`), nil).Once()
+
+				return m
+			},
+			args: args{
+				ctx: t.Context(),
+				source: &file.ContentFile{
+					FileName:  "foo",
+					OriginDir: "hello",
+					Content:   "안녕, 세계!",
+					Language:  config.LanguageCodeEnglish,
+				},
+			},
+			want:    "Hello, world!",
+			wantErr: false,
+		},
+		{
+			name: "placeholder를 잘못 옮긴 번역은 다시 요청",
+			fields: fields{
+				cfg: &Config{
+					SourceLanguage: config.LanguageCodeKorean,
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+					},
+					Model: openai.ChatModelGPT4oMini,
+				},
+			},
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"markdown":"⟦0⟧ and ⟦0⟧ is a language."}`), nil).Once()
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"markdown":"⟦0⟧ is a language."}`), nil).Once()
+
+				return m
+			},
+			args: args{
+				ctx: t.Context(),
+				source: &file.ContentFile{
+					FileName:  "foo",
+					OriginDir: "hello",
+					Content:   "`go`는 언어입니다.",
+					Language:  config.LanguageCodeEnglish,
+				},
+			},
+			want:    "`go` is a language.",
+			wantErr: false,
+		},
+		{
+			name: "다시 요청해도 구조가 다르면 실패",
+			fields: fields{
+				cfg: &Config{
+					SourceLanguage: config.LanguageCodeKorean,
+					TargetLanguages: config.LanguageCodes{
+						config.LanguageCodeEnglish,
+					},
+					Model: openai.ChatModelGPT4oMini,
+				},
+			},
+			mockClient: func() llm.OpenAIClient {
+				m := mocks.NewOpenAIClient(t)
+				m.EXPECT().New(mock.Anything, mock.Anything).Return(completion(`{"markdown":"# Hello, world!"}`), nil).Times(MaxStructureRetries + 1)
+
+				return m
+			},
+			args: args{
+				ctx: t.Context(),
+				source: &file.ContentFile{
 					FileName:  "foo",
 					OriginDir: "hello",
 					Content:   "안녕, 세계!",
@@ -240,6 +330,120 @@ English
 	}
 }
 
+func Test_translator_Translate_Templates(t *testing.T) {
+	templates, err := ParseTemplates(
+		"Translate {{ .Path }} in the {{ .Section }} section.",
+		"{{ range .Glossary }}{{ .Term }}={{ .Translation }};{{ end }}{{ .Previous }}|{{ .Source }}",
+	)
+	assert.NoError(t, err)
+
+	m := mocks.NewOpenAIClient(t)
+	m.EXPECT().New(mock.Anything, openai.ChatCompletionNewParams{
+		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
+			openai.ChatCompletionDeveloperMessageParam{
+				Role: openai.F(openai.ChatCompletionDeveloperMessageParamRoleDeveloper),
+				Content: openai.F([]openai.ChatCompletionContentPartTextParam{
+					{
+						Text: openai.F("Translate post/foo.md in the post section."),
+						Type: openai.F(openai.ChatCompletionContentPartTextTypeText),
+					},
+				}),
+			},
+			openai.UserMessage("쿠버네티스=Kubernetes;# Kubernetes|쿠버네티스 입문"),
+		}),
+		ResponseFormat: openai.F[openai.ChatCompletionNewParamsResponseFormatUnion](
+			openai.ResponseFormatJSONSchemaParam{
+				Type: openai.F(openai.ResponseFormatJSONSchemaTypeJSONSchema),
+				JSONSchema: openai.F(openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:        openai.F("markdown"),
+					Description: openai.F("translated markdown"),
+					Schema:      openai.F(TranslateMarkdownSchema()),
+					Strict:      openai.Bool(true),
+				}),
+			}),
+		Model: openai.F(openai.ChatModelGPT4oMini),
+	}).Return(completion(`{"markdown":"Introduction to Kubernetes"}`), nil).Once()
+
+	tr := translator{
+		client: m,
+		cfg: &Config{
+			SourceLanguage: config.LanguageCodeKorean,
+			Model:          openai.ChatModelGPT4oMini,
+			Templates:      templates,
+			Glossary: Glossary{
+				"쿠버네티스": {config.LanguageCodeEnglish: "Kubernetes"},
+				"파드":    {config.LanguageCodeEnglish: "Pod"},
+			},
+		},
+	}
+	source := &file.ContentFile{
+		SourcePath: "post/foo.md",
+		FileName:   "foo",
+		OriginDir:  "post",
+		Content:    "쿠버네티스 입문",
+		Previous:   "# Kubernetes",
+		Language:   config.LanguageCodeEnglish,
+	}
+
+	assert.NoError(t, tr.Translate(t.Context(), source))
+	assert.Equal(t, file.Markdown("Introduction to Kubernetes"), source.Translated)
+}
+
+func Test_translator_Translate_ChangedParagraphs(t *testing.T) {
+	templates, err := ParseTemplates("Translate.", "{{ .PreviousSource }}|{{ .Source }}")
+	assert.NoError(t, err)
+
+	// 바뀐 문단만 이전 원본 없이 번역 요청
+	m := mocks.NewOpenAIClient(t)
+	m.EXPECT().New(mock.Anything, openai.ChatCompletionNewParams{
+		Messages: openai.F([]openai.ChatCompletionMessageParamUnion{
+			openai.ChatCompletionDeveloperMessageParam{
+				Role: openai.F(openai.ChatCompletionDeveloperMessageParamRoleDeveloper),
+				Content: openai.F([]openai.ChatCompletionContentPartTextParam{
+					{
+						Text: openai.F("Translate."),
+						Type: openai.F(openai.ChatCompletionContentPartTextTypeText),
+					},
+				}),
+			},
+			openai.UserMessage("|둘째 문단"),
+		}),
+		ResponseFormat: openai.F[openai.ChatCompletionNewParamsResponseFormatUnion](
+			openai.ResponseFormatJSONSchemaParam{
+				Type: openai.F(openai.ResponseFormatJSONSchemaTypeJSONSchema),
+				JSONSchema: openai.F(openai.ResponseFormatJSONSchemaJSONSchemaParam{
+					Name:        openai.F("markdown"),
+					Description: openai.F("translated markdown"),
+					Schema:      openai.F(TranslateMarkdownSchema()),
+					Strict:      openai.Bool(true),
+				}),
+			}),
+		Model: openai.F(openai.ChatModelGPT4oMini),
+	}).Return(completion(`{"markdown":"Second paragraph\n"}`), nil).Once()
+
+	tr := translator{
+		client: m,
+		cfg: &Config{
+			SourceLanguage: config.LanguageCodeKorean,
+			Model:          openai.ChatModelGPT4oMini,
+			Templates:      templates,
+		},
+	}
+	source := &file.ContentFile{
+		SourcePath:     "post/foo.md",
+		FileName:       "foo",
+		OriginDir:      "post",
+		Ext:            ".md",
+		Content:        "# 안녕\n\n첫 문단\n\n둘째 문단\n",
+		PreviousSource: "# 안녕\n\n첫 문단\n\n셋째 문단\n",
+		Previous:       "# Hello\n\nFirst paragraph\n\nThird paragraph\n",
+		Language:       config.LanguageCodeEnglish,
+	}
+
+	assert.NoError(t, tr.Translate(t.Context(), source))
+	assert.Equal(t, file.Markdown("# Hello\n\nFirst paragraph\n\nSecond paragraph\n"), source.Translated)
+}
+
 func TestNew(t *testing.T) {
 	type args struct {
 		client llm.OpenAIClient
@@ -276,3 +480,45 @@ func TestNew(t *testing.T) {
 		})
 	}
 }
+
+func TestConfig_instruction(t *testing.T) {
+	cfg := Config{
+		SourceLanguage:  config.LanguageCodeKorean,
+		SiteInstruction: "Keep product names in English.\n",
+		LanguageInstructions: map[config.LanguageCode]string{
+			config.LanguageCodeGerman:  "Use the formal register (Sie).",
+			config.LanguageCodeSpanish: "  \n",
+		},
+	}
+
+	tests := []struct {
+		name string
+		cfg  Config
+		to   config.LanguageCode
+		want string
+	}{
+		{
+			name: "사이트 전체, 언어별 지침 순으로 덧붙임",
+			cfg:  cfg,
+			to:   config.LanguageCodeGerman,
+			want: instructionMd + "\n\nKeep product names in English.\n\nUse the formal register (Sie).",
+		},
+		{
+			name: "언어별 지침이 없거나 비어있는 경우",
+			cfg:  cfg,
+			to:   config.LanguageCodeSpanish,
+			want: instructionMd + "\n\nKeep product names in English.",
+		},
+		{
+			name: "지침이 없는 경우",
+			cfg:  Config{SourceLanguage: config.LanguageCodeKorean},
+			to:   config.LanguageCodeEnglish,
+			want: instructionMd,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.cfg.instruction(instructionMd, tt.to))
+		})
+	}
+}